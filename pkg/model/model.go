@@ -28,6 +28,7 @@ type Edge struct {
 	Angle12    Angle  `json:"angle12"`
 	Angle21    Angle  `json:"angle21"`
 	IsDirected bool   `json:"isDirected"`
+	Weight     float64 `json:"weight"`
 }
 
 type Angle struct {
@@ -6,11 +6,13 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	"github.com/illfate2/graph-api/pkg/model"
 	"github.com/illfate2/graph-api/pkg/service"
+	"github.com/illfate2/graph-api/pkg/service/graph"
 )
 
 type Server struct {
@@ -29,16 +31,31 @@ func New(service service.Service) *Server {
 	r.Use(CORS)
 
 	r.HandleFunc("/api/v1/graph", s.CreateGraph).Methods(http.MethodPost)
+	r.HandleFunc("/api/v1/graph/fromPrufer", s.FromPrufer).Methods(http.MethodPost)
+	r.HandleFunc("/api/v1/generate/fromDegreeSequence", s.FromDegreeSequence).Methods(http.MethodPost)
+	r.HandleFunc("/api/v1/generate", s.Generate).
+		Queries("kind", "{kind:complete|completeBipartite|cycle|path|star|wheel|grid|hypercube|petersen|erdosRenyi|wattsStrogatz|barabasiAlbert}").
+		Methods(http.MethodPost)
 	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}", s.Graph).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}", s.UpdateGraph).Methods(http.MethodPut)
 	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}", s.DeleteGraph).Methods(http.MethodDelete)
 	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/adjacencyMatrix", s.AdjacencyMatrix).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/incidenceMatrix", s.IncidenceMatrix).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/reachabilityMatrix", s.ReachabilityMatrix).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/transitiveClosure", s.TransitiveClosure).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/transitiveReduction", s.TransitiveReduction).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/diameter", s.FindDiameter).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/radius", s.FindRadius).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/tree", s.Tree).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/center", s.FindCenter).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/kCenter", s.KCenter).
+		Queries("k", "{k:[1-9]+[0-9]*}").Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/dominatingSet", s.DominatingSet).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{ids:[1-9]+[0-9]*[,][1-9]+[0-9]*}/{operation:union|intersection|difference|symmetricDifference|join}",
+		s.Combine).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/graph/{ids:[1-9]+[0-9]*[,][1-9]+[0-9]*}/cartesian", s.Cartesian).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{ids:[1-9]+[0-9]*[,][1-9]+[0-9]*}/product", s.Product).
+		Queries("kind", "{kind:cartesian|tensor|strong|lexicographic|rooted}").Methods(http.MethodGet)
 
 	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/shortestPath", s.ShortestPath).
 		Queries("fromNode", "{fromNode}", "toNode", "{toNode}").Methods(http.MethodGet)
@@ -57,6 +74,47 @@ func New(service service.Service) *Server {
 	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/planarCheck", s.PlanarCheck).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/planarReduction", s.PlanarReduction).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/isTree", s.IsTree).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/rootTree", s.RootTree).
+		Queries("root", "{root}").Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/lca", s.LowestCommonAncestor).
+		Queries("root", "{root}", "nodes", "{nodes}").Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/prufer", s.PruferCode).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/centroid", s.Centroid).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{ids:[1-9]+[0-9]*[,][1-9]+[0-9]*}/treeIsomorphic", s.TreeIsomorphic).
+		Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/patternSearch", s.FindPattern).Methods(http.MethodPost)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/centrality", s.Centrality).
+		Queries("kind", "{kind:degree|closeness|betweenness|eigenvector|pagerank}").Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/communities", s.Communities).
+		Queries("kind", "{kind:louvain|labelPropagation}").Methods(http.MethodGet, http.MethodPut)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/stats", s.Stats).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/spectrum", s.Spectrum).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/spanningTreeCount", s.SpanningTreeCount).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/transform", s.Transform).
+		Queries("kind", "{kind:complement|lineGraph|transpose|underlying|power|subdivision}").
+		Methods(http.MethodGet, http.MethodPost)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/subgraph", s.InducedSubgraph).
+		Queries("nodes", "{nodes}").Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/ego", s.Ego).
+		Queries("node", "{node}").Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/contractEdge", s.ContractEdge).
+		Queries("edge", "{edge}").Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/mergeNodes", s.MergeNodes).
+		Queries("nodes", "{nodes}").Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/dominators", s.Dominators).
+		Queries("root", "{root}").Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/kcore", s.Cores).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/cycles", s.Cycles).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/connectivity", s.Connectivity).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/gomoryHuTree", s.GomoryHuTree).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/steinerTree", s.SteinerTree).
+		Queries("terminals", "{terminals}").Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/layout", s.Layout).
+		Queries("kind", "{kind:circular|forceDirected|spring|hierarchical|planar|tree|grid}").
+		Methods(http.MethodGet, http.MethodPut)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/geometry", s.Geometry).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/crossings", s.Crossings).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/graph/{id:[1-9]+[0-9]*}/untangle", s.Untangle).Methods(http.MethodGet, http.MethodPut)
 	r.HandleFunc("/api/v1/graph/list", s.GraphList).Methods(http.MethodGet)
 	return &s
 }
@@ -180,6 +238,63 @@ func (s *Server) AdjacencyMatrix(w http.ResponseWriter, req *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+func (s *Server) ReachabilityMatrix(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	m, err := s.service.ReachabilityMatrix(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Matrix string `json:"matrix"`
+	}{
+		Matrix: m.String(),
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) TransitiveClosure(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	res, err := s.service.TransitiveClosure(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Closure model.Graph `json:"closure"`
+	}{
+		Closure: res,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) TransitiveReduction(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	res, err := s.service.TransitiveReduction(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Reduction model.Graph `json:"reduction"`
+	}{
+		Reduction: res,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
 func (s *Server) ShortestPath(w http.ResponseWriter, req *http.Request) {
 	args, err := getShortestPathArgs(req)
 	if err != nil {
@@ -237,6 +352,56 @@ func (s *Server) Cartesian(w http.ResponseWriter, req *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+func (s *Server) Product(w http.ResponseWriter, req *http.Request) {
+	firstID, secondID, err := getIDs(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	kind := graph.ProductKind(mux.Vars(req)["kind"])
+	p, err := s.service.Product(firstID, secondID, kind)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Product model.Graph `json:"product"`
+	}{
+		Product: p,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// Combine applies a set operation to two graphs. Nodes are matched by ID
+// unless the matchBy query parameter is "name".
+func (s *Server) Combine(w http.ResponseWriter, req *http.Request) {
+	firstID, secondID, err := getIDs(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	matchBy := graph.MatchBy(req.URL.Query().Get("matchBy"))
+	if matchBy == "" {
+		matchBy = graph.MatchByID
+	}
+	if matchBy != graph.MatchByID && matchBy != graph.MatchByName {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	op := graph.SetOperation(mux.Vars(req)["operation"])
+	res, err := s.service.Combine(firstID, secondID, op, matchBy)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Graph model.Graph `json:"graph"`
+	}{
+		Graph: res,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
 func (s *Server) FindCenter(w http.ResponseWriter, req *http.Request) {
 	id, err := getID(req)
 	if err != nil {
@@ -256,6 +421,39 @@ func (s *Server) FindCenter(w http.ResponseWriter, req *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+func (s *Server) KCenter(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	k, err := getSpecificID(req, "k")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c, err := s.service.KCenter(id, k)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(c)
+}
+
+func (s *Server) DominatingSet(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	set, err := s.service.DominatingSet(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(set)
+}
+
 func (s *Server) FindRadius(w http.ResponseWriter, req *http.Request) {
 	id, err := getID(req)
 	if err != nil {
@@ -414,69 +612,840 @@ func (s *Server) AllPaths(w http.ResponseWriter, req *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
-func getID(req *http.Request) (uint64, error) {
-	return getSpecificID(req, "id")
+// RootTree returns parents and depths of the tree nodes when it hangs from
+// the root query node.
+func (s *Server) RootTree(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	root, err := getSpecificID(req, "root")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	tree, err := s.service.RootTree(id, root)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(tree)
 }
 
-func getIDs(req *http.Request) (first, second uint64, err error) {
-	vars := mux.Vars(req)
-	ids := strings.Split(vars["ids"], ",")
-	first, err = strconv.ParseUint(ids[0], 10, 64)
+// LowestCommonAncestor returns the deepest common ancestor of the nodes
+// listed in the nodes query parameter.
+func (s *Server) LowestCommonAncestor(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	root, err := getSpecificID(req, "root")
 	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
-	second, err = strconv.ParseUint(ids[1], 10, 64)
-	return
+	nodes, err := getIDList(req, "nodes")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	lca, err := s.service.LowestCommonAncestor(id, root, nodes)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		LCA uint64 `json:"lca"`
+	}{
+		LCA: lca,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
 }
 
-type shortestPathArgs struct {
-	graphID  uint64
-	fromNode uint64
-	toNode   uint64
+func (s *Server) PruferCode(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	code, err := s.service.PruferCode(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Code []uint64 `json:"code"`
+	}{
+		Code: code,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
 }
 
-func getShortestPathArgs(req *http.Request) (shortestPathArgs, error) {
-	id, err := getID(req)
+// FromPrufer creates a tree from a Prüfer code given in the request body.
+func (s *Server) FromPrufer(w http.ResponseWriter, req *http.Request) {
+	var body struct {
+		Name string   `json:"name"`
+		Code []uint64 `json:"code"`
+	}
+	err := json.NewDecoder(req.Body).Decode(&body)
 	if err != nil {
-		return shortestPathArgs{}, err
+		w.WriteHeader(http.StatusBadRequest)
+		log.Print("Error when decoding JSON: ", err)
+		return
 	}
-	fromNode, err := getSpecificID(req, "fromNode")
+	tree, err := s.service.FromPrufer(body.Name, body.Code)
 	if err != nil {
-		return shortestPathArgs{}, err
+		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
-	toNode, err := getSpecificID(req, "toNode")
+	resp := struct {
+		Graph model.Graph `json:"graph"`
+	}{
+		Graph: tree,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// FromDegreeSequence creates a graph with the degrees given in the request
+// body. Directed graphs are requested with outDegrees and inDegrees instead
+// of degrees. A sequence no graph realises is answered with the first
+// inequality it fails.
+func (s *Server) FromDegreeSequence(w http.ResponseWriter, req *http.Request) {
+	var body struct {
+		Name       string   `json:"name"`
+		Degrees    []uint64 `json:"degrees"`
+		OutDegrees []uint64 `json:"outDegrees"`
+		InDegrees  []uint64 `json:"inDegrees"`
+	}
+	err := json.NewDecoder(req.Body).Decode(&body)
 	if err != nil {
-		return shortestPathArgs{}, err
+		w.WriteHeader(http.StatusBadRequest)
+		log.Print("Error when decoding JSON: ", err)
+		return
 	}
-	return shortestPathArgs{
-		graphID:  id,
-		fromNode: fromNode,
-		toNode:   toNode,
-	}, nil
+	var (
+		check graph.GraphicalCheck
+		res   model.Graph
+	)
+	if body.OutDegrees != nil || body.InDegrees != nil {
+		check, res, err = s.service.FromDirectedDegreeSequence(body.Name, body.OutDegrees, body.InDegrees)
+	} else {
+		check, res, err = s.service.FromDegreeSequence(body.Name, body.Degrees)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		graph.GraphicalCheck
+		Graph *model.Graph `json:"graph,omitempty"`
+	}{
+		GraphicalCheck: check,
+	}
+	if check.Graphical {
+		resp.Graph = &res
+	}
+	_ = json.NewEncoder(w).Encode(resp)
 }
 
-type pathArgs struct {
-	graphID     uint64
-	startedNode uint64
+// Generate creates a graph of the family given by kind. Sizes are read from
+// the n, m and k query parameters, the probability of random graphs from p
+// and their seed from seed, which defaults to the current time.
+func (s *Server) Generate(w http.ResponseWriter, req *http.Request) {
+	kind := graph.GeneratorKind(mux.Vars(req)["kind"])
+	var (
+		params graph.GeneratorParams
+		err    error
+	)
+	for name, value := range map[string]*uint64{"n": &params.N, "m": &params.M, "k": &params.K} {
+		*value, err = getUintQuery(req, name)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	if p := req.URL.Query().Get("p"); p != "" {
+		params.P, err = strconv.ParseFloat(p, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	params.Seed = time.Now().UnixNano()
+	if seed := req.URL.Query().Get("seed"); seed != "" {
+		params.Seed, err = strconv.ParseInt(seed, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	res, err := s.service.Generate(req.URL.Query().Get("name"), kind, params)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Graph model.Graph `json:"graph"`
+	}{
+		Graph: res,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
 }
 
-func getPathArgs(req *http.Request) (pathArgs, error) {
+func (s *Server) TreeIsomorphic(w http.ResponseWriter, req *http.Request) {
+	firstID, secondID, err := getIDs(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	isomorphic, err := s.service.TreeIsomorphic(firstID, secondID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Isomorphic bool `json:"isomorphic"`
+	}{
+		Isomorphic: isomorphic,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) Centroid(w http.ResponseWriter, req *http.Request) {
 	id, err := getID(req)
 	if err != nil {
-		return pathArgs{}, err
+		w.WriteHeader(http.StatusBadRequest)
+		return
 	}
-	startedNode, err := getSpecificID(req, "startNode")
+	centroid, err := s.service.Centroid(id)
 	if err != nil {
-		return pathArgs{}, err
+		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
-	return pathArgs{
-		graphID:     id,
-		startedNode: startedNode,
-	}, nil
+	resp := struct {
+		Centroid []uint64 `json:"centroid"`
+	}{
+		Centroid: centroid,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
 }
 
-func getSpecificID(req *http.Request, idName string) (uint64, error) {
-	vars := mux.Vars(req)
-	id, err := strconv.ParseUint(vars[idName], 10, 64)
-	return id, err
+// FindPattern streams every occurrence of the pattern graph from the request
+// body as newline delimited JSON, one match per line. A failure after the
+// stream started ends it with an error line.
+func (s *Server) FindPattern(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	induced, err := getBoolQuery(req, "induced")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	limit, err := getUintQuery(req, "limit")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var pattern model.Graph
+	err = json.NewDecoder(req.Body).Decode(&pattern)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		log.Print("Error when decoding JSON: ", err)
+		return
+	}
+
+	// Errors can only be reported with a status before the first line.
+	_, err = s.service.Graph(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+	err = s.service.FindPattern(id, pattern, induced, limit, func(match graph.Match) bool {
+		resp := struct {
+			Match graph.Match `json:"match"`
+		}{
+			Match: match,
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return false
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return true
+	})
+	if err != nil {
+		log.Print("Error when searching pattern: ", err)
+		resp := struct {
+			Error string `json:"error"`
+		}{
+			Error: err.Error(),
+		}
+		_ = encoder.Encode(resp)
+	}
+}
+
+func (s *Server) Centrality(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	normalized, err := getBoolQuery(req, "normalized")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	kind := graph.CentralityKind(mux.Vars(req)["kind"])
+	scores, err := s.service.Centrality(id, kind, normalized)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Centrality map[uint64]float64 `json:"centrality"`
+	}{
+		Centrality: scores,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// Communities detects communities of the graph given by kind. GET only
+// shows them, PUT also stores a distinct node color for every community.
+func (s *Server) Communities(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	kind := graph.CommunityKind(mux.Vars(req)["kind"])
+	communities, err := s.service.Communities(id, kind, req.Method == http.MethodPut)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(communities)
+}
+
+func (s *Server) Stats(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	stats, err := s.service.Stats(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(stats)
+}
+
+func (s *Server) Spectrum(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	spectrum, err := s.service.Spectrum(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(spectrum)
+}
+
+// SpanningTreeCount counts spanning trees, or arborescences rooted at the
+// node given by the optional root query parameter.
+func (s *Server) SpanningTreeCount(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var count graph.SpanningTreeCount
+	if req.URL.Query().Get("root") == "" {
+		count, err = s.service.SpanningTreeCount(id)
+	} else {
+		var root uint64
+		root, err = getUintQuery(req, "root")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		count, err = s.service.ArborescenceCount(id, root)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(count)
+}
+
+// Transform builds a new graph out of the stored one with the transform
+// given by kind. GET only shows the result, POST stores it as a new graph.
+func (s *Server) Transform(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	k, err := getUintQuery(req, "k")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	kind := graph.TransformKind(mux.Vars(req)["kind"])
+	res, err := s.service.Transform(id, kind, k, req.Method == http.MethodPost)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Graph model.Graph `json:"graph"`
+	}{
+		Graph: res,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// Layout moves nodes of the graph to positions computed by the layout
+// given by kind. GET only shows the positions, PUT stores them.
+func (s *Server) Layout(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	kind := graph.LayoutKind(mux.Vars(req)["kind"])
+	res, err := s.service.Layout(id, kind, req.Method == http.MethodPut)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Graph model.Graph `json:"graph"`
+	}{
+		Graph: res,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) Geometry(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	geometry, err := s.service.Geometry(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(geometry)
+}
+
+func (s *Server) Crossings(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	crossings, err := s.service.Crossings(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(crossings)
+}
+
+// Untangle moves nodes of the graph to reduce edge crossings. Moves are
+// random, driven by the optional seed. GET only shows the positions, PUT
+// stores them.
+func (s *Server) Untangle(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	seed := time.Now().UnixNano()
+	if value := req.URL.Query().Get("seed"); value != "" {
+		seed, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	res, err := s.service.Untangle(id, seed, req.Method == http.MethodPut)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Graph model.Graph `json:"graph"`
+	}{
+		Graph: res,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) InducedSubgraph(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	nodes, err := getIDList(req, "nodes")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	res, err := s.service.InducedSubgraph(id, nodes)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Subgraph model.Graph `json:"subgraph"`
+	}{
+		Subgraph: res,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// Ego returns the neighbourhood of a node, one hop wide unless the radius
+// query parameter says otherwise.
+func (s *Server) Ego(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	node, err := getSpecificID(req, "node")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	radius := uint64(1)
+	if req.URL.Query().Get("radius") != "" {
+		radius, err = getUintQuery(req, "radius")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	res, err := s.service.Ego(id, node, radius)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Ego model.Graph `json:"ego"`
+	}{
+		Ego: res,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) ContractEdge(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	edge, err := getSpecificID(req, "edge")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	args, err := getContractionArgs(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	res, err := s.service.ContractEdge(id, edge, args.keepParallel, args.keepLoops)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Graph model.Graph `json:"graph"`
+	}{
+		Graph: res,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) MergeNodes(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	nodes, err := getIDList(req, "nodes")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	args, err := getContractionArgs(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	res, err := s.service.MergeNodes(id, nodes, args.keepParallel, args.keepLoops)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Graph model.Graph `json:"graph"`
+	}{
+		Graph: res,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) Dominators(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	root, err := getSpecificID(req, "root")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	dominators, err := s.service.Dominators(id, root)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(dominators)
+}
+
+// Cores returns core numbers of all nodes and the k-core for the k query
+// parameter, or the main core when it's missing.
+func (s *Server) Cores(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	k, err := getUintQuery(req, "k")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	cores, err := s.service.Cores(id, k)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(cores)
+}
+
+// Cycles returns cycle bases and girth of the graph. Simple cycles are
+// listed too when enumerate is set, at most limit of them.
+func (s *Server) Cycles(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	enumerate, err := getBoolQuery(req, "enumerate")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	limit, err := getUintQuery(req, "limit")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	cycles, err := s.service.Cycles(id, enumerate, limit)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(cycles)
+}
+
+func (s *Server) Connectivity(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	connectivity, err := s.service.Connectivity(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(connectivity)
+}
+
+func (s *Server) GomoryHuTree(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	tree, err := s.service.GomoryHuTree(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Tree model.Graph `json:"tree"`
+	}{
+		Tree: tree,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) SteinerTree(w http.ResponseWriter, req *http.Request) {
+	id, err := getID(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	terminals, err := getIDList(req, "terminals")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	tree, err := s.service.SteinerTree(id, terminals)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(tree)
+}
+
+func getID(req *http.Request) (uint64, error) {
+	return getSpecificID(req, "id")
+}
+
+func getIDs(req *http.Request) (first, second uint64, err error) {
+	vars := mux.Vars(req)
+	ids := strings.Split(vars["ids"], ",")
+	first, err = strconv.ParseUint(ids[0], 10, 64)
+	if err != nil {
+		return
+	}
+	second, err = strconv.ParseUint(ids[1], 10, 64)
+	return
+}
+
+type shortestPathArgs struct {
+	graphID  uint64
+	fromNode uint64
+	toNode   uint64
+}
+
+func getShortestPathArgs(req *http.Request) (shortestPathArgs, error) {
+	id, err := getID(req)
+	if err != nil {
+		return shortestPathArgs{}, err
+	}
+	fromNode, err := getSpecificID(req, "fromNode")
+	if err != nil {
+		return shortestPathArgs{}, err
+	}
+	toNode, err := getSpecificID(req, "toNode")
+	if err != nil {
+		return shortestPathArgs{}, err
+	}
+	return shortestPathArgs{
+		graphID:  id,
+		fromNode: fromNode,
+		toNode:   toNode,
+	}, nil
+}
+
+type pathArgs struct {
+	graphID     uint64
+	startedNode uint64
+}
+
+func getPathArgs(req *http.Request) (pathArgs, error) {
+	id, err := getID(req)
+	if err != nil {
+		return pathArgs{}, err
+	}
+	startedNode, err := getSpecificID(req, "startNode")
+	if err != nil {
+		return pathArgs{}, err
+	}
+	return pathArgs{
+		graphID:     id,
+		startedNode: startedNode,
+	}, nil
+}
+
+type contractionArgs struct {
+	keepParallel bool
+	keepLoops    bool
+}
+
+func getContractionArgs(req *http.Request) (contractionArgs, error) {
+	keepParallel, err := getBoolQuery(req, "keepParallel")
+	if err != nil {
+		return contractionArgs{}, err
+	}
+	keepLoops, err := getBoolQuery(req, "keepLoops")
+	if err != nil {
+		return contractionArgs{}, err
+	}
+	return contractionArgs{
+		keepParallel: keepParallel,
+		keepLoops:    keepLoops,
+	}, nil
+}
+
+// getIDList parses a comma separated list of IDs from the route variable.
+func getIDList(req *http.Request, name string) ([]uint64, error) {
+	vars := mux.Vars(req)
+	parts := strings.Split(vars[name], ",")
+	ids := make([]uint64, 0, len(parts))
+	for _, p := range parts {
+		id, err := strconv.ParseUint(strings.TrimSpace(p), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func getSpecificID(req *http.Request, idName string) (uint64, error) {
+	vars := mux.Vars(req)
+	id, err := strconv.ParseUint(vars[idName], 10, 64)
+	return id, err
+}
+
+func getBoolQuery(req *http.Request, name string) (bool, error) {
+	value := req.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+func getUintQuery(req *http.Request, name string) (uint64, error) {
+	value := req.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
 }
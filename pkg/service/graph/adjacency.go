@@ -0,0 +1,207 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+// adjacency is an index based view of a graph. Nodes are sorted by ID and
+// addressed by their position, which keeps the heavier algorithms away from
+// maps keyed by model.Node.
+type adjacency struct {
+	nodes    []model.Node
+	index    map[uint64]int
+	out      [][]arc
+	in       [][]arc
+	weighted bool
+}
+
+// arc is one traversable direction of an edge. Its weight is 1 unless some
+// edge of the graph carries a weight.
+type arc struct {
+	to     int
+	edge   int
+	weight float64
+}
+
+// newAdjacency builds a view that respects edge directions: a directed edge
+// is a single arc and an undirected edge is an arc in both directions.
+func newAdjacency(graph model.Graph) adjacency {
+	return buildAdjacency(graph, false)
+}
+
+// newUndirectedAdjacency builds a view where every edge is treated as
+// undirected.
+func newUndirectedAdjacency(graph model.Graph) adjacency {
+	return buildAdjacency(graph, true)
+}
+
+func buildAdjacency(graph model.Graph, ignoreDirection bool) adjacency {
+	nodes := graphNodes(graph)
+	adj := adjacency{
+		nodes: nodes,
+		index: make(map[uint64]int, len(nodes)),
+		out:   make([][]arc, len(nodes)),
+		in:    make([][]arc, len(nodes)),
+	}
+	for i, n := range nodes {
+		adj.index[n.ID] = i
+	}
+	adj.weighted = isWeighted(graph)
+	for i, e := range graph.Edges {
+		from, to := adj.index[e.From.ID], adj.index[e.To.ID]
+		w := edgeWeight(e, adj.weighted)
+		adj.out[from] = append(adj.out[from], arc{to: to, edge: i, weight: w})
+		adj.in[to] = append(adj.in[to], arc{to: from, edge: i, weight: w})
+		if (ignoreDirection || !e.IsDirected) && from != to {
+			adj.out[to] = append(adj.out[to], arc{to: from, edge: i, weight: w})
+			adj.in[from] = append(adj.in[from], arc{to: to, edge: i, weight: w})
+		}
+	}
+	return adj
+}
+
+// unweighted returns a copy of the view where every arc weighs 1.
+func (a adjacency) unweighted() adjacency {
+	res := a
+	res.weighted = false
+	res.out = make([][]arc, len(a.out))
+	res.in = make([][]arc, len(a.in))
+	for v := range a.nodes {
+		res.out[v] = make([]arc, len(a.out[v]))
+		for i, w := range a.out[v] {
+			w.weight = 1
+			res.out[v][i] = w
+		}
+		res.in[v] = make([]arc, len(a.in[v]))
+		for i, w := range a.in[v] {
+			w.weight = 1
+			res.in[v][i] = w
+		}
+	}
+	return res
+}
+
+// arcCounts returns how many arcs lead from every node to its out neighbours.
+func (a adjacency) arcCounts() []map[int]int {
+	counts := make([]map[int]int, len(a.nodes))
+	for v := range a.nodes {
+		counts[v] = make(map[int]int, len(a.out[v]))
+		for _, w := range a.out[v] {
+			counts[v][w.to]++
+		}
+	}
+	return counts
+}
+
+// neighbourSets returns distinct neighbours of every node, leaving out
+// self loops.
+func (a adjacency) neighbourSets() []map[int]struct{} {
+	sets := make([]map[int]struct{}, len(a.nodes))
+	for v := range a.nodes {
+		sets[v] = make(map[int]struct{}, len(a.out[v]))
+		for _, w := range a.out[v] {
+			if w.to != v {
+				sets[v][w.to] = struct{}{}
+			}
+		}
+	}
+	return sets
+}
+
+// components labels every node with the index of its connected component,
+// following arcs of the view in both directions.
+func (a adjacency) components() ([]int, int) {
+	labels := make([]int, len(a.nodes))
+	for i := range labels {
+		labels[i] = -1
+	}
+	count := 0
+	for v := range a.nodes {
+		if labels[v] != -1 {
+			continue
+		}
+		labels[v] = count
+		stack := []int{v}
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, arcs := range [][]arc{a.out[u], a.in[u]} {
+				for _, w := range arcs {
+					if labels[w.to] == -1 {
+						labels[w.to] = count
+						stack = append(stack, w.to)
+					}
+				}
+			}
+		}
+		count++
+	}
+	return labels, count
+}
+
+// graphNodes returns nodes of the graph sorted by ID. Unlike setNodes it
+// keeps isolated nodes from graph.Nodes and prefers their attributes over
+// the copies stored inside edges.
+func graphNodes(graph model.Graph) []model.Node {
+	byID := make(map[uint64]model.Node)
+	for _, e := range graph.Edges {
+		byID[e.From.ID] = e.From
+		byID[e.To.ID] = e.To
+	}
+	for _, n := range graph.Nodes {
+		byID[n.ID] = n
+	}
+	nodes := make([]model.Node, 0, len(byID))
+	for _, n := range byID {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes
+}
+
+// hasDirectedEdges reports whether the graph has at least one one-way edge.
+func hasDirectedEdges(graph model.Graph) bool {
+	for _, e := range graph.Edges {
+		if e.IsDirected && e.From.ID != e.To.ID {
+			return true
+		}
+	}
+	return false
+}
+
+var ErrNegativeWeight = errors.New("edge weights must not be negative")
+
+// isWeighted reports whether any edge of the graph carries a weight.
+func isWeighted(graph model.Graph) bool {
+	for _, e := range graph.Edges {
+		if e.Weight != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// edgeWeight is the weight of the edge in a weighted graph. Edges without a
+// weight, 0, weigh 1 like all edges of unweighted graphs.
+func edgeWeight(e model.Edge, weighted bool) float64 {
+	if !weighted || e.Weight == 0 {
+		return 1
+	}
+	return e.Weight
+}
+
+// checkWeights rejects graphs with negative edge weights, which shortest
+// path searches don't support.
+func checkWeights(graph model.Graph) error {
+	for _, e := range graph.Edges {
+		if e.Weight < 0 {
+			return ErrNegativeWeight
+		}
+	}
+	return nil
+}
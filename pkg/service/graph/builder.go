@@ -0,0 +1,52 @@
+package graph
+
+import (
+	"strconv"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+// builder assembles a new graph, numbering nodes and edges from 1 in the
+// order they are added. Edges get copies of their endpoints, like graphs
+// coming from the API do.
+type builder struct {
+	graph model.Graph
+}
+
+func newBuilder(name string) *builder {
+	return &builder{graph: model.Graph{Name: name}}
+}
+
+// addNode copies node attributes and assigns a fresh ID.
+func (b *builder) addNode(n model.Node) model.Node {
+	n.ID = uint64(len(b.graph.Nodes) + 1)
+	b.graph.Nodes = append(b.graph.Nodes, n)
+	return n
+}
+
+// addEdge copies edge attributes, connects it to the given nodes and
+// assigns a fresh ID.
+func (b *builder) addEdge(e model.Edge, from, to model.Node) model.Edge {
+	e.ID = uint64(len(b.graph.Edges) + 1)
+	e.From = from
+	e.To = to
+	b.graph.Edges = append(b.graph.Edges, e)
+	return e
+}
+
+// addNumberedNodes adds n nodes named after their IDs.
+func (b *builder) addNumberedNodes(n int) []model.Node {
+	nodes := make([]model.Node, n)
+	for i := range nodes {
+		nodes[i] = b.addNode(model.Node{Name: strconv.Itoa(len(b.graph.Nodes) + 1)})
+	}
+	return nodes
+}
+
+func (b *builder) connect(from, to model.Node, isDirected bool) model.Edge {
+	return b.addEdge(model.Edge{IsDirected: isDirected}, from, to)
+}
+
+func (b *builder) result() model.Graph {
+	return b.graph
+}
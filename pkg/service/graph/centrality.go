@@ -0,0 +1,220 @@
+package graph
+
+import (
+	"errors"
+	"math"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+type CentralityKind string
+
+const (
+	DegreeCentrality      CentralityKind = "degree"
+	ClosenessCentrality   CentralityKind = "closeness"
+	BetweennessCentrality CentralityKind = "betweenness"
+	EigenvectorCentrality CentralityKind = "eigenvector"
+	PageRankCentrality    CentralityKind = "pagerank"
+)
+
+const (
+	pageRankDamping = 0.85
+	maxIterations   = 1000
+	convergence     = 1e-10
+)
+
+var ErrUnknownKind = errors.New("unknown kind")
+
+// Centrality returns the score of every node keyed by node ID. Weighted
+// graphs use edge weights as degrees and as distances, edges without a
+// weight count as 1 and negative weights are rejected.
+func (g Graph) Centrality(graph model.Graph, kind CentralityKind, normalized bool) (map[uint64]float64, error) {
+	if err := checkWeights(graph); err != nil {
+		return nil, err
+	}
+	adj := newAdjacency(graph)
+	var scores []float64
+	switch kind {
+	case DegreeCentrality:
+		scores = degreeCentrality(graph, adj, normalized)
+	case ClosenessCentrality:
+		scores = closenessCentrality(adj, normalized)
+	case BetweennessCentrality:
+		scores = betweennessCentrality(adj, !hasDirectedEdges(graph), normalized)
+	case EigenvectorCentrality:
+		scores = eigenvectorCentrality(adj, normalized)
+	case PageRankCentrality:
+		scores = pageRank(adj, normalized)
+	default:
+		return nil, ErrUnknownKind
+	}
+	res := make(map[uint64]float64, len(scores))
+	for i, s := range scores {
+		res[adj.nodes[i].ID] = s
+	}
+	return res, nil
+}
+
+func degreeCentrality(graph model.Graph, adj adjacency, normalized bool) []float64 {
+	scores := make([]float64, len(adj.nodes))
+	for _, e := range graph.Edges {
+		w := edgeWeight(e, adj.weighted)
+		scores[adj.index[e.From.ID]] += w
+		scores[adj.index[e.To.ID]] += w
+	}
+	if normalized && len(scores) > 1 {
+		scale(scores, 1/float64(len(scores)-1))
+	}
+	return scores
+}
+
+// closenessCentrality is the inverse of the total distance to reachable
+// nodes. Normalized scores follow Wasserman and Faust, so nodes of small
+// components don't outrank the ones of the giant component.
+func closenessCentrality(adj adjacency, normalized bool) []float64 {
+	scores := make([]float64, len(adj.nodes))
+	for v := range adj.nodes {
+		tree := adj.shortestPaths(v)
+		var total float64
+		for _, u := range tree.order {
+			total += tree.dist[u]
+		}
+		if total == 0 {
+			continue
+		}
+		scores[v] = 1 / total
+		if normalized {
+			reached := float64(len(tree.order) - 1)
+			scores[v] = reached / total * reached / float64(len(adj.nodes)-1)
+		}
+	}
+	return scores
+}
+
+// betweennessCentrality is Brandes' algorithm.
+func betweennessCentrality(adj adjacency, undirected, normalized bool) []float64 {
+	n := len(adj.nodes)
+	scores := make([]float64, n)
+	delta := make([]float64, n)
+	for s := range adj.nodes {
+		tree := adj.shortestPaths(s)
+		for _, v := range tree.order {
+			delta[v] = 0
+		}
+		for i := len(tree.order) - 1; i >= 0; i-- {
+			w := tree.order[i]
+			for _, v := range tree.preds[w] {
+				delta[v] += tree.sigma[v] / tree.sigma[w] * (1 + delta[w])
+			}
+			if w != s {
+				scores[w] += delta[w]
+			}
+		}
+	}
+	if undirected {
+		scale(scores, 0.5)
+	}
+	if normalized && n > 2 {
+		pairs := float64((n - 1) * (n - 2))
+		if undirected {
+			pairs /= 2
+		}
+		scale(scores, 1/pairs)
+	}
+	return scores
+}
+
+// eigenvectorCentrality runs power iteration on A+I, which has the same
+// principal eigenvector as A but also converges on bipartite graphs.
+func eigenvectorCentrality(adj adjacency, normalized bool) []float64 {
+	n := len(adj.nodes)
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1 / math.Sqrt(float64(n))
+	}
+	for iter := 0; iter < maxIterations; iter++ {
+		next := make([]float64, n)
+		for v := range adj.nodes {
+			next[v] = x[v]
+			for _, u := range adj.in[v] {
+				next[v] += u.weight * x[u.to]
+			}
+		}
+		var norm float64
+		for _, s := range next {
+			norm += s * s
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			break
+		}
+		scale(next, 1/norm)
+		var diff float64
+		for i := range next {
+			diff += math.Abs(next[i] - x[i])
+		}
+		x = next
+		if diff < float64(n)*convergence {
+			break
+		}
+	}
+	if normalized {
+		scaleToMax(x)
+	}
+	return x
+}
+
+func pageRank(adj adjacency, normalized bool) []float64 {
+	n := len(adj.nodes)
+	rank := make([]float64, n)
+	outWeight := make([]float64, n)
+	for v := range adj.nodes {
+		rank[v] = 1 / float64(n)
+		for _, w := range adj.out[v] {
+			outWeight[v] += w.weight
+		}
+	}
+	for iter := 0; iter < maxIterations; iter++ {
+		var dangling float64
+		for v := range adj.nodes {
+			if outWeight[v] == 0 {
+				dangling += rank[v]
+			}
+		}
+		next := make([]float64, n)
+		for v := range adj.nodes {
+			next[v] = (1-pageRankDamping)/float64(n) + pageRankDamping*dangling/float64(n)
+			for _, u := range adj.in[v] {
+				next[v] += pageRankDamping * rank[u.to] * u.weight / outWeight[u.to]
+			}
+		}
+		var diff float64
+		for i := range next {
+			diff += math.Abs(next[i] - rank[i])
+		}
+		rank = next
+		if diff < convergence {
+			break
+		}
+	}
+	if normalized {
+		scaleToMax(rank)
+	}
+	return rank
+}
+
+func scale(values []float64, factor float64) {
+	for i := range values {
+		values[i] *= factor
+	}
+}
+
+func scaleToMax(values []float64) {
+	var max float64
+	for _, v := range values {
+		max = math.Max(max, math.Abs(v))
+	}
+	if max != 0 {
+		scale(values, 1/max)
+	}
+}
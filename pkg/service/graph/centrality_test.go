@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_Centrality(t *testing.T) {
+	star := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{1, 4})
+	cycle := edgesGraph(true, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1})
+	weighted := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{1, 3})
+	weighted.Edges[0].Weight = 1
+	weighted.Edges[1].Weight = 1
+	weighted.Edges[2].Weight = 5
+	missing := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{1, 3})
+	missing.Edges[1].Weight = 1
+	missing.Edges[2].Weight = 1.5
+
+	type args struct {
+		graph      model.Graph
+		kind       CentralityKind
+		normalized bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[uint64]float64
+	}{
+		{
+			name: "degree",
+			args: args{graph: star, kind: DegreeCentrality},
+			want: map[uint64]float64{1: 3, 2: 1, 3: 1, 4: 1},
+		},
+		{
+			name: "normalized degree",
+			args: args{graph: star, kind: DegreeCentrality, normalized: true},
+			want: map[uint64]float64{1: 1, 2: 1.0 / 3, 3: 1.0 / 3, 4: 1.0 / 3},
+		},
+		{
+			name: "closeness",
+			args: args{graph: star, kind: ClosenessCentrality, normalized: true},
+			want: map[uint64]float64{1: 1, 2: 0.6, 3: 0.6, 4: 0.6},
+		},
+		{
+			name: "betweenness",
+			args: args{graph: star, kind: BetweennessCentrality},
+			want: map[uint64]float64{1: 3, 2: 0, 3: 0, 4: 0},
+		},
+		{
+			name: "weighted betweenness",
+			args: args{graph: weighted, kind: BetweennessCentrality},
+			want: map[uint64]float64{1: 0, 2: 1, 3: 0},
+		},
+		{
+			name: "missing weights count as 1",
+			args: args{graph: missing, kind: BetweennessCentrality},
+			want: map[uint64]float64{1: 0, 2: 0, 3: 0},
+		},
+		{
+			name: "eigenvector",
+			args: args{graph: cycle, kind: EigenvectorCentrality, normalized: true},
+			want: map[uint64]float64{1: 1, 2: 1, 3: 1},
+		},
+		{
+			name: "pagerank",
+			args: args{graph: cycle, kind: PageRankCentrality},
+			want: map[uint64]float64{1: 1.0 / 3, 2: 1.0 / 3, 3: 1.0 / 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := Graph{}
+			got, err := g.Centrality(tt.args.graph, tt.args.kind, tt.args.normalized)
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+			for id, score := range tt.want {
+				assert.InDelta(t, score, got[id], 1e-6, "node %d", id)
+			}
+		})
+	}
+}
+
+func TestGraph_CentralityUnknownKind(t *testing.T) {
+	_, err := Graph{}.Centrality(model.Graph{}, "unknown", false)
+	assert.Equal(t, ErrUnknownKind, err)
+}
+
+func TestGraph_CentralityNegativeWeight(t *testing.T) {
+	graph := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3})
+	graph.Edges[0].Weight = -1
+
+	_, err := Graph{}.Centrality(graph, ClosenessCentrality, false)
+	assert.Equal(t, ErrNegativeWeight, err)
+}
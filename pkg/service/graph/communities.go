@@ -0,0 +1,283 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+type CommunityKind string
+
+const (
+	LouvainCommunities          CommunityKind = "louvain"
+	LabelPropagationCommunities CommunityKind = "labelPropagation"
+)
+
+type Communities struct {
+	Membership map[uint64]int `json:"membership"`
+	Modularity float64        `json:"modularity"`
+}
+
+// Communities partitions nodes of the graph, treating every edge as
+// undirected. Community IDs are numbered from 0 in order of the smallest
+// node ID they contain. Negative edge weights are rejected.
+func (g Graph) Communities(graph model.Graph, kind CommunityKind) (Communities, error) {
+	if err := checkWeights(graph); err != nil {
+		return Communities{}, err
+	}
+	w := newWeightMatrix(newUndirectedAdjacency(graph))
+	var membership []int
+	switch kind {
+	case LouvainCommunities:
+		membership = louvain(w)
+	case LabelPropagationCommunities:
+		membership = labelPropagation(w)
+	default:
+		return Communities{}, ErrUnknownKind
+	}
+	membership = renumber(membership)
+
+	nodes := graphNodes(graph)
+	res := Communities{
+		Membership: make(map[uint64]int, len(nodes)),
+		Modularity: w.modularity(membership),
+	}
+	for i, n := range nodes {
+		res.Membership[n.ID] = membership[i]
+	}
+	return res, nil
+}
+
+// ColorCommunities returns a copy of the graph where every community got a
+// distinct node color.
+func (g Graph) ColorCommunities(graph model.Graph, membership map[uint64]int) model.Graph {
+	return updateNodes(graph, func(n model.Node) model.Node {
+		if c, ok := membership[n.ID]; ok {
+			n.Color = distinctColor(c)
+		}
+		return n
+	})
+}
+
+// weightMatrix is a symmetric weighted adjacency where a self loop of
+// weight w is stored as 2w, so that degrees are plain row sums.
+type weightMatrix []map[int]float64
+
+func newWeightMatrix(adj adjacency) weightMatrix {
+	w := make(weightMatrix, len(adj.nodes))
+	for v := range adj.nodes {
+		w[v] = make(map[int]float64)
+		for _, a := range adj.out[v] {
+			if a.to == v {
+				w[v][v] += 2 * a.weight
+			} else {
+				w[v][a.to] += a.weight
+			}
+		}
+	}
+	return w
+}
+
+func (w weightMatrix) degrees() ([]float64, float64) {
+	degrees := make([]float64, len(w))
+	var total float64
+	for v := range w {
+		for _, weight := range w[v] {
+			degrees[v] += weight
+		}
+		total += degrees[v]
+	}
+	return degrees, total
+}
+
+func (w weightMatrix) modularity(membership []int) float64 {
+	degrees, total := w.degrees()
+	if total == 0 {
+		return 0
+	}
+	inside := make(map[int]float64)
+	tot := make(map[int]float64)
+	for v := range w {
+		tot[membership[v]] += degrees[v]
+		for u, weight := range w[v] {
+			if membership[u] == membership[v] {
+				inside[membership[v]] += weight
+			}
+		}
+	}
+	var q float64
+	for c, t := range tot {
+		q += inside[c]/total - (t/total)*(t/total)
+	}
+	return q
+}
+
+// louvain repeats local moving of nodes and aggregation of communities
+// into single nodes until no move improves modularity.
+func louvain(w weightMatrix) []int {
+	membership := make([]int, len(w))
+	for i := range membership {
+		membership[i] = i
+	}
+	for {
+		level, moved := louvainLevel(w)
+		if !moved {
+			return membership
+		}
+		level = renumber(level)
+		for i := range membership {
+			membership[i] = level[membership[i]]
+		}
+		w = w.aggregate(level)
+	}
+}
+
+func louvainLevel(w weightMatrix) ([]int, bool) {
+	degrees, total := w.degrees()
+	community := make([]int, len(w))
+	tot := make([]float64, len(w))
+	for v := range w {
+		community[v] = v
+		tot[v] = degrees[v]
+	}
+	if total == 0 {
+		return community, false
+	}
+	moved := false
+	for improved := true; improved; {
+		improved = false
+		for v := range w {
+			current := community[v]
+			tot[current] -= degrees[v]
+			links := make(map[int]float64)
+			for u, weight := range w[v] {
+				if u != v {
+					links[community[u]] += weight
+				}
+			}
+			best, bestGain := current, links[current]-tot[current]*degrees[v]/total
+			for c, link := range links {
+				gain := link - tot[c]*degrees[v]/total
+				if gain > bestGain+1e-12 || gain >= bestGain-1e-12 && c < best && best != current {
+					best, bestGain = c, gain
+				}
+			}
+			community[v] = best
+			tot[best] += degrees[v]
+			if best != current {
+				improved = true
+				moved = true
+			}
+		}
+	}
+	return community, moved
+}
+
+func (w weightMatrix) aggregate(community []int) weightMatrix {
+	size := 0
+	for _, c := range community {
+		if c+1 > size {
+			size = c + 1
+		}
+	}
+	res := make(weightMatrix, size)
+	for c := range res {
+		res[c] = make(map[int]float64)
+	}
+	for v := range w {
+		for u, weight := range w[v] {
+			res[community[v]][community[u]] += weight
+		}
+	}
+	return res
+}
+
+// labelPropagation lets every node adopt the label carrying the most edge
+// weight among its neighbours until labels stop changing. Nodes are visited
+// in random order and ties are broken randomly unless the current label is
+// among the best; the fixed seed keeps results reproducible.
+func labelPropagation(w weightMatrix) []int {
+	rng := rand.New(rand.NewSource(1))
+	labels := make([]int, len(w))
+	for i := range labels {
+		labels[i] = i
+	}
+	for iter := 0; iter < maxIterations; iter++ {
+		changed := false
+		for _, v := range rng.Perm(len(w)) {
+			weights := make(map[int]float64)
+			for u, weight := range w[v] {
+				if u != v {
+					weights[labels[u]] += weight
+				}
+			}
+			var (
+				best       []int
+				bestWeight float64
+			)
+			for label, weight := range weights {
+				if weight > bestWeight {
+					best, bestWeight = best[:0], weight
+				}
+				if weight == bestWeight {
+					best = append(best, label)
+				}
+			}
+			if len(best) == 0 || weights[labels[v]] == bestWeight {
+				continue
+			}
+			sort.Ints(best)
+			labels[v] = best[rng.Intn(len(best))]
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+	return labels
+}
+
+// renumber maps community labels onto 0..k-1 in order of first appearance.
+func renumber(membership []int) []int {
+	ids := make(map[int]int)
+	res := make([]int, len(membership))
+	for i, c := range membership {
+		id, ok := ids[c]
+		if !ok {
+			id = len(ids)
+			ids[c] = id
+		}
+		res[i] = id
+	}
+	return res
+}
+
+// distinctColor spreads hues by the golden angle, so neighbouring indexes
+// get easily distinguishable colors.
+func distinctColor(i int) string {
+	hue := math.Mod(float64(i)*137.508, 360)
+	const saturation, lightness = 0.65, 0.5
+	chroma := (1 - math.Abs(2*lightness-1)) * saturation
+	x := chroma * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g, b = chroma, x, 0
+	case hue < 120:
+		r, g, b = x, chroma, 0
+	case hue < 180:
+		r, g, b = 0, chroma, x
+	case hue < 240:
+		r, g, b = 0, x, chroma
+	case hue < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+	m := lightness - chroma/2
+	return fmt.Sprintf("#%02x%02x%02x",
+		int(math.Round((r+m)*255)), int(math.Round((g+m)*255)), int(math.Round((b+m)*255)))
+}
@@ -0,0 +1,52 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_Communities(t *testing.T) {
+	// Two K4 connected by a single bridge 4-5.
+	graph := edgesGraph(false,
+		[2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{1, 4}, [2]uint64{2, 3}, [2]uint64{2, 4}, [2]uint64{3, 4},
+		[2]uint64{5, 6}, [2]uint64{5, 7}, [2]uint64{5, 8}, [2]uint64{6, 7}, [2]uint64{6, 8}, [2]uint64{7, 8},
+		[2]uint64{4, 5},
+	)
+	want := Communities{
+		Membership: map[uint64]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 1, 6: 1, 7: 1, 8: 1},
+		Modularity: 2 * (12.0/26 - (13.0/26)*(13.0/26)),
+	}
+
+	for _, kind := range []CommunityKind{LouvainCommunities, LabelPropagationCommunities} {
+		t.Run(string(kind), func(t *testing.T) {
+			got, err := Graph{}.Communities(graph, kind)
+			require.NoError(t, err)
+			assert.Equal(t, want.Membership, got.Membership)
+			assert.InDelta(t, want.Modularity, got.Modularity, 1e-9)
+		})
+	}
+}
+
+func TestGraph_CommunitiesNegativeWeight(t *testing.T) {
+	graph := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3})
+	graph.Edges[0].Weight = -1
+
+	_, err := Graph{}.Communities(graph, LouvainCommunities)
+	assert.Equal(t, ErrNegativeWeight, err)
+}
+
+func TestGraph_ColorCommunities(t *testing.T) {
+	graph := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{3, 4})
+	graph.Nodes = []model.Node{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+
+	got := Graph{}.ColorCommunities(graph, map[uint64]int{1: 0, 2: 0, 3: 1, 4: 1})
+	assert.Equal(t, got.Nodes[0].Color, got.Nodes[1].Color)
+	assert.NotEqual(t, got.Nodes[0].Color, got.Nodes[2].Color)
+	assert.Equal(t, got.Nodes[0].Color, got.Edges[0].From.Color)
+	assert.Equal(t, got.Nodes[3].Color, got.Edges[1].To.Color)
+	assert.Empty(t, graph.Nodes[0].Color)
+}
@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+// Connectivity holds the vertex connectivity κ and the edge connectivity λ
+// of a graph with a minimum set of nodes and edges whose removal separates
+// it. A complete graph can't be separated by removing nodes, so its κ is
+// n-1 and SeparatingNodes is empty.
+type Connectivity struct {
+	Vertex          uint64   `json:"vertex"`
+	Edge            uint64   `json:"edge"`
+	SeparatingNodes []uint64 `json:"separatingNodes"`
+	SeparatingEdges []uint64 `json:"separatingEdges"`
+}
+
+// Connectivity computes connectivity numbers with unit capacity max-flows,
+// ignoring edge weights. Edge directions are respected, so a graph with
+// directed edges is measured by how hard it is to break strong
+// connectivity.
+func (g Graph) Connectivity(graph model.Graph) Connectivity {
+	adj := newAdjacency(graph)
+	res := Connectivity{}
+	if len(adj.nodes) < 2 {
+		return res
+	}
+	directed := hasDirectedEdges(graph)
+	res.Edge, res.SeparatingEdges = edgeConnectivity(graph, adj, directed)
+	res.Vertex, res.SeparatingNodes = vertexConnectivity(adj, directed)
+	return res
+}
+
+// edgeConnectivity is the smallest flow between the first node and any
+// other, in both directions for directed graphs, as every cut separates
+// the first node from some node.
+func edgeConnectivity(graph model.Graph, adj adjacency, directed bool) (uint64, []uint64) {
+	network := newFlowNetwork(len(adj.nodes))
+	for v := range adj.nodes {
+		for _, a := range adj.out[v] {
+			if a.to != v {
+				network.addArc(v, a.to, 1, a.edge)
+			}
+		}
+	}
+	best, bestSide := -1.0, []bool(nil)
+	cut := func(s, t int) {
+		network.reset()
+		if flow := network.maxFlow(s, t); best < 0 || flow < best {
+			best, bestSide = flow, network.reachable(s)
+		}
+	}
+	for t := 1; t < len(adj.nodes) && best != 0; t++ {
+		cut(0, t)
+		if directed {
+			cut(t, 0)
+		}
+	}
+
+	seen := make(map[int]struct{})
+	var edges []uint64
+	for v := range adj.nodes {
+		for _, a := range adj.out[v] {
+			if _, ok := seen[a.edge]; !ok && bestSide[v] && !bestSide[a.to] {
+				seen[a.edge] = struct{}{}
+				edges = append(edges, graph.Edges[a.edge].ID)
+			}
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i] < edges[j] })
+	return uint64(best), edges
+}
+
+// vertexConnectivity follows Even's algorithm: node v is split into v and
+// n+v joined by an arc of capacity 1, and flows are computed between non
+// adjacent pairs where the first node is among the first κ+1 nodes, one
+// of which lies outside any minimum separator.
+func vertexConnectivity(adj adjacency, directed bool) (uint64, []uint64) {
+	n := len(adj.nodes)
+	network := newFlowNetwork(2 * n)
+	adjacent := make([]map[int]struct{}, n)
+	for v := range adj.nodes {
+		network.addArc(v, n+v, 1, -1)
+		adjacent[v] = make(map[int]struct{})
+		for _, a := range adj.out[v] {
+			if a.to != v {
+				network.addArc(n+v, a.to, float64(n), -1)
+				adjacent[v][a.to] = struct{}{}
+			}
+		}
+	}
+	best, bestSide := n-1, []bool(nil)
+	cut := func(s, t int) {
+		if _, ok := adjacent[s][t]; ok {
+			return
+		}
+		network.reset()
+		if flow := int(network.maxFlow(n+s, t) + 0.5); flow < best {
+			best, bestSide = flow, network.reachable(n+s)
+		}
+	}
+	for i := 0; i <= best && i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			cut(i, j)
+			if directed {
+				cut(j, i)
+			}
+		}
+	}
+
+	var nodes []uint64
+	if bestSide != nil {
+		for v := range adj.nodes {
+			if bestSide[v] && !bestSide[n+v] {
+				nodes = append(nodes, adj.nodes[v].ID)
+			}
+		}
+	}
+	return uint64(best), nodes
+}
@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_Connectivity(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph model.Graph
+		want  Connectivity
+	}{
+		{
+			name:  "path",
+			graph: edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}),
+			want:  Connectivity{Vertex: 1, Edge: 1, SeparatingNodes: []uint64{2}, SeparatingEdges: []uint64{1}},
+		},
+		{
+			name: "two triangles sharing a node",
+			graph: edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1},
+				[2]uint64{3, 4}, [2]uint64{4, 5}, [2]uint64{5, 3}),
+			want: Connectivity{Vertex: 1, Edge: 2, SeparatingNodes: []uint64{3}, SeparatingEdges: []uint64{1, 3}},
+		},
+		{
+			name: "complete",
+			graph: edgesGraph(false, [2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{1, 4},
+				[2]uint64{2, 3}, [2]uint64{2, 4}, [2]uint64{3, 4}),
+			want: Connectivity{Vertex: 3, Edge: 3, SeparatingEdges: []uint64{1, 2, 3}},
+		},
+		{
+			name:  "disconnected",
+			graph: edgesGraph(false, [2]uint64{1, 2}, [2]uint64{3, 4}),
+			want:  Connectivity{},
+		},
+		{
+			name:  "directed cycle",
+			graph: edgesGraph(true, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 4}, [2]uint64{4, 1}),
+			want:  Connectivity{Vertex: 1, Edge: 1, SeparatingNodes: []uint64{3}, SeparatingEdges: []uint64{1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Graph{}.Connectivity(tt.graph))
+		})
+	}
+}
+
+func TestGraph_ConnectivityOfCube(t *testing.T) {
+	graph := edgesGraph(false,
+		[2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 4}, [2]uint64{4, 1},
+		[2]uint64{5, 6}, [2]uint64{6, 7}, [2]uint64{7, 8}, [2]uint64{8, 5},
+		[2]uint64{1, 5}, [2]uint64{2, 6}, [2]uint64{3, 7}, [2]uint64{4, 8})
+
+	got := Graph{}.Connectivity(graph)
+	assert.Equal(t, uint64(3), got.Vertex)
+	assert.Equal(t, uint64(3), got.Edge)
+	assert.Len(t, got.SeparatingNodes, 3)
+	assert.Len(t, got.SeparatingEdges, 3)
+
+	keep := make(map[uint64]struct{})
+	for _, n := range graphNodes(graph) {
+		keep[n.ID] = struct{}{}
+	}
+	for _, id := range got.SeparatingNodes {
+		delete(keep, id)
+	}
+	_, components := newAdjacency(inducedSubgraph(graph, keep)).components()
+	assert.Greater(t, components, 1)
+}
@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+var ErrEdgeNotFound = errors.New("edge not found")
+
+// ContractEdge merges endpoints of the edge into one node and removes the
+// edge itself. See MergeNodes for the handling of the remaining edges.
+func (g Graph) ContractEdge(graph model.Graph, edgeID uint64, keepParallel, keepLoops bool) (model.Graph, error) {
+	for i, e := range graph.Edges {
+		if e.ID != edgeID {
+			continue
+		}
+		contracted := graph
+		contracted.Edges = make([]model.Edge, 0, len(graph.Edges)-1)
+		contracted.Edges = append(contracted.Edges, graph.Edges[:i]...)
+		contracted.Edges = append(contracted.Edges, graph.Edges[i+1:]...)
+		contracted.Nodes = graphNodes(graph)
+		return g.MergeNodes(contracted, []uint64{e.From.ID, e.To.ID}, keepParallel, keepLoops)
+	}
+	return model.Graph{}, ErrEdgeNotFound
+}
+
+// MergeNodes replaces the given nodes with a single new node placed at
+// their centroid. It takes the next free ID and other attributes of the
+// first merged node. Edges between merged nodes turn into self loops and
+// edges to a common neighbour into parallel edges; unless asked to keep
+// them, both are dropped. Loops and parallel edges the graph had before
+// are kept. Other nodes and edges keep their IDs.
+func (g Graph) MergeNodes(graph model.Graph, nodeIDs []uint64, keepParallel, keepLoops bool) (model.Graph, error) {
+	nodes := graphNodes(graph)
+	byID := make(map[uint64]model.Node, len(nodes))
+	var maxID uint64
+	for _, n := range nodes {
+		byID[n.ID] = n
+		if n.ID > maxID {
+			maxID = n.ID
+		}
+	}
+	if len(nodeIDs) == 0 {
+		return model.Graph{}, ErrNodeNotFound
+	}
+	merged := make(map[uint64]struct{}, len(nodeIDs))
+	var sumX, sumY float64
+	for _, id := range nodeIDs {
+		n, ok := byID[id]
+		if !ok {
+			return model.Graph{}, ErrNodeNotFound
+		}
+		if _, ok := merged[id]; ok {
+			continue
+		}
+		merged[id] = struct{}{}
+		sumX += float64(n.X)
+		sumY += float64(n.Y)
+	}
+	node := byID[nodeIDs[0]]
+	node.ID = maxID + 1
+	node.X = uint64(sumX/float64(len(merged)) + 0.5)
+	node.Y = uint64(sumY/float64(len(merged)) + 0.5)
+
+	res := graph
+	res.Nodes = []model.Node{node}
+	for _, n := range nodes {
+		if _, ok := merged[n.ID]; !ok {
+			res.Nodes = append(res.Nodes, n)
+		}
+	}
+	res.Edges = nil
+	type pair struct {
+		from, to   uint64
+		isDirected bool
+	}
+	// Edges already parallel or loops before the merge are always kept, so
+	// every pair remembers the original pair of its first edge.
+	key := func(from, to uint64, isDirected bool) pair {
+		if !isDirected && from > to {
+			from, to = to, from
+		}
+		return pair{from: from, to: to, isDirected: isDirected}
+	}
+	seen := make(map[pair]pair)
+	for _, e := range graph.Edges {
+		original := key(e.From.ID, e.To.ID, e.IsDirected)
+		_, from := merged[e.From.ID]
+		_, to := merged[e.To.ID]
+		if from {
+			e.From = node
+		}
+		if to {
+			e.To = node
+		}
+		if from && to && original.from != original.to && !keepLoops {
+			continue
+		}
+		p := key(e.From.ID, e.To.ID, e.IsDirected)
+		if first, ok := seen[p]; !ok {
+			seen[p] = original
+		} else if first != original && !keepParallel {
+			continue
+		}
+		res.Edges = append(res.Edges, e)
+	}
+	return res, nil
+}
@@ -0,0 +1,68 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_ContractEdge(t *testing.T) {
+	graph := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1}, [2]uint64{3, 4})
+	graph.Nodes = []model.Node{{ID: 1, X: 0, Y: 0, Name: "a"}, {ID: 2, X: 100, Y: 50}, {ID: 3}, {ID: 4}}
+
+	tests := []struct {
+		name         string
+		keepParallel bool
+		want         [][2]uint64
+	}{
+		{
+			name: "drop parallel",
+			want: [][2]uint64{{5, 3}, {3, 4}},
+		},
+		{
+			name:         "keep parallel",
+			keepParallel: true,
+			want:         [][2]uint64{{5, 3}, {3, 5}, {3, 4}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Graph{}.ContractEdge(graph, 1, tt.keepParallel, false)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, edgePairs(got))
+			assert.Equal(t, model.Node{ID: 5, X: 50, Y: 25, Name: "a"}, got.Nodes[0])
+			assert.Len(t, got.Nodes, 3)
+		})
+	}
+
+	_, err := Graph{}.ContractEdge(graph, 42, false, false)
+	assert.Equal(t, ErrEdgeNotFound, err)
+}
+
+func TestGraph_MergeNodes(t *testing.T) {
+	graph := edgesGraph(true, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1}, [2]uint64{3, 4})
+
+	got, err := Graph{}.MergeNodes(graph, []uint64{1, 2, 3}, false, true)
+	require.NoError(t, err)
+	assert.Equal(t, [][2]uint64{{5, 5}, {5, 4}}, edgePairs(got))
+
+	got, err = Graph{}.MergeNodes(graph, []uint64{1, 2, 3}, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, [][2]uint64{{5, 4}}, edgePairs(got))
+	assert.Equal(t, uint64(4), got.Edges[0].ID)
+
+	_, err = Graph{}.MergeNodes(graph, []uint64{1, 42}, false, false)
+	assert.Equal(t, ErrNodeNotFound, err)
+}
+
+func TestGraph_MergeNodesKeepsExistingLoopsAndParallelEdges(t *testing.T) {
+	graph := edgesGraph(false, [2]uint64{1, 1}, [2]uint64{1, 4}, [2]uint64{1, 4}, [2]uint64{2, 4},
+		[2]uint64{1, 2}, [2]uint64{3, 4}, [2]uint64{3, 4})
+
+	got, err := Graph{}.MergeNodes(graph, []uint64{1, 2}, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, [][2]uint64{{5, 5}, {5, 4}, {5, 4}, {3, 4}, {3, 4}}, edgePairs(got))
+}
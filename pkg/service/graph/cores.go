@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+type Cores struct {
+	CoreNumbers map[uint64]uint64 `json:"coreNumbers"`
+	Degeneracy  uint64            `json:"degeneracy"`
+	Ordering    []uint64          `json:"ordering"`
+	Core        model.Graph       `json:"core"`
+}
+
+// Cores computes core numbers of nodes with the Batagelj and Zaversnik
+// algorithm on the underlying simple graph. Ordering lists nodes in the
+// order they were peeled off, so every node has at most Degeneracy
+// neighbours later in it. Core is the subgraph induced by nodes with core
+// number at least k, where k of 0 selects the main core.
+func (g Graph) Cores(graph model.Graph, k uint64) Cores {
+	adj := newUndirectedAdjacency(graph)
+	core, order := coreNumbers(adj)
+	res := Cores{
+		CoreNumbers: make(map[uint64]uint64, len(adj.nodes)),
+		Ordering:    make([]uint64, 0, len(order)),
+	}
+	for v, c := range core {
+		res.CoreNumbers[adj.nodes[v].ID] = uint64(c)
+		if uint64(c) > res.Degeneracy {
+			res.Degeneracy = uint64(c)
+		}
+	}
+	for _, v := range order {
+		res.Ordering = append(res.Ordering, adj.nodes[v].ID)
+	}
+	if k == 0 {
+		k = res.Degeneracy
+	}
+	keep := make(map[uint64]struct{})
+	for id, c := range res.CoreNumbers {
+		if c >= k {
+			keep[id] = struct{}{}
+		}
+	}
+	res.Core = inducedSubgraph(graph, keep)
+	return res
+}
+
+// coreNumbers returns core numbers and the degeneracy ordering of nodes.
+func coreNumbers(adj adjacency) ([]int, []int) {
+	sets := adj.neighbourSets()
+	n := len(sets)
+	degree := make([]int, n)
+	maxDegree := 0
+	for v := range sets {
+		degree[v] = len(sets[v])
+		if degree[v] > maxDegree {
+			maxDegree = degree[v]
+		}
+	}
+	// Nodes are kept in vert sorted by current degree, bin[d] points at the
+	// first node of degree d and pos is the inverse of vert.
+	bin := make([]int, maxDegree+1)
+	for _, d := range degree {
+		bin[d]++
+	}
+	start := 0
+	for d := range bin {
+		start, bin[d] = start+bin[d], start
+	}
+	pos, vert := make([]int, n), make([]int, n)
+	for v, d := range degree {
+		pos[v] = bin[d]
+		vert[pos[v]] = v
+		bin[d]++
+	}
+	for d := maxDegree; d > 0; d-- {
+		bin[d] = bin[d-1]
+	}
+	if n > 0 {
+		bin[0] = 0
+	}
+	for i := 0; i < n; i++ {
+		v := vert[i]
+		for u := range sets[v] {
+			if degree[u] <= degree[v] {
+				continue
+			}
+			du, pu := degree[u], pos[u]
+			pw := bin[du]
+			if w := vert[pw]; u != w {
+				pos[u], pos[w] = pw, pu
+				vert[pu], vert[pw] = w, u
+			}
+			bin[du]++
+			degree[u]--
+		}
+	}
+	return degree, vert
+}
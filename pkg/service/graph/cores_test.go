@@ -0,0 +1,71 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph_Cores(t *testing.T) {
+	// A 4-clique with a triangle hanging off node 4 and a pendant node 8.
+	graph := edgesGraph(false,
+		[2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{1, 4}, [2]uint64{2, 3}, [2]uint64{2, 4}, [2]uint64{3, 4},
+		[2]uint64{4, 5}, [2]uint64{5, 6}, [2]uint64{6, 4}, [2]uint64{6, 7}, [2]uint64{7, 8})
+
+	tests := []struct {
+		name string
+		k    uint64
+		want [][2]uint64
+	}{
+		{
+			name: "main core",
+			k:    0,
+			want: [][2]uint64{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}},
+		},
+		{
+			name: "2-core",
+			k:    2,
+			want: [][2]uint64{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}, {4, 5}, {5, 6}, {6, 4}},
+		},
+		{
+			name: "empty core",
+			k:    4,
+			want: [][2]uint64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Graph{}.Cores(graph, tt.k)
+			assert.Equal(t, map[uint64]uint64{1: 3, 2: 3, 3: 3, 4: 3, 5: 2, 6: 2, 7: 1, 8: 1}, got.CoreNumbers)
+			assert.Equal(t, uint64(3), got.Degeneracy)
+			assert.Equal(t, tt.want, edgePairs(got.Core))
+		})
+	}
+}
+
+func TestGraph_CoresOrdering(t *testing.T) {
+	graph := edgesGraph(true,
+		[2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1}, [2]uint64{3, 4}, [2]uint64{4, 5}, [2]uint64{5, 5})
+
+	got := Graph{}.Cores(graph, 0)
+	assert.Equal(t, uint64(2), got.Degeneracy)
+	assert.Len(t, got.Ordering, 5)
+	position := make(map[uint64]int)
+	for i, id := range got.Ordering {
+		position[id] = i
+	}
+	later := make(map[uint64]uint64)
+	for _, e := range graph.Edges {
+		if e.From.ID == e.To.ID {
+			continue
+		}
+		if position[e.From.ID] < position[e.To.ID] {
+			later[e.From.ID]++
+		} else {
+			later[e.To.ID]++
+		}
+	}
+	for id, count := range later {
+		assert.LessOrEqual(t, count, got.Degeneracy, "node %d", id)
+	}
+}
@@ -0,0 +1,409 @@
+package graph
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+// Cycle lists nodes in the order they are visited and the edges between
+// them, where Edges[i] joins Nodes[i] with the next node of the cycle.
+type Cycle struct {
+	Nodes  []uint64 `json:"nodes"`
+	Edges  []uint64 `json:"edges"`
+	Weight float64  `json:"weight"`
+}
+
+type Cycles struct {
+	FundamentalBasis   []Cycle    `json:"fundamentalBasis"`
+	MinimumBasis       []Cycle    `json:"minimumBasis"`
+	MinimumBasisWeight float64    `json:"minimumBasisWeight"`
+	Girth              uint64     `json:"girth"`
+	SimpleCycles       [][]uint64 `json:"simpleCycles,omitempty"`
+}
+
+// Cycles returns cycle bases of the graph and its girth, all computed on
+// the undirected view, so that a basis spans every cycle of the graph.
+// When enumerate is set, simple cycles are listed by node IDs as well,
+// following edge directions; at most limit of them are listed, 0 means
+// no limit. Negative edge weights are rejected.
+func (g Graph) Cycles(graph model.Graph, enumerate bool, limit uint64) (Cycles, error) {
+	if err := checkWeights(graph); err != nil {
+		return Cycles{}, err
+	}
+	adj := newUndirectedAdjacency(graph)
+	res := Cycles{
+		FundamentalBasis: fundamentalCycles(graph, adj),
+		MinimumBasis:     minimumCycleBasis(graph, adj),
+		Girth:            girth(adj),
+	}
+	for _, c := range res.MinimumBasis {
+		res.MinimumBasisWeight += c.Weight
+	}
+	if enumerate {
+		res.SimpleCycles = simpleCycles(graph, limit)
+	}
+	return res, nil
+}
+
+// cycleRank is the dimension of the cycle space of the undirected view.
+func cycleRank(graph model.Graph, adj adjacency) int {
+	_, components := adj.components()
+	return len(graph.Edges) - len(adj.nodes) + components
+}
+
+// pathTree returns a shortest path tree rooted at source as parent nodes
+// and parent edges, with -1 for the source and unreachable nodes.
+func (a adjacency) pathTree(source int) ([]int, []int, []float64) {
+	tree := a.shortestPaths(source)
+	parent := make([]int, len(a.nodes))
+	parentEdge := make([]int, len(a.nodes))
+	for i := range parent {
+		parent[i], parentEdge[i] = -1, -1
+	}
+	for _, w := range tree.order {
+		if w == source {
+			continue
+		}
+		p := tree.preds[w][0]
+		parent[w] = p
+		for _, x := range a.out[p] {
+			if x.to == w && sameDistance(tree.dist[p]+x.weight, tree.dist[w]) {
+				parentEdge[w] = x.edge
+				break
+			}
+		}
+	}
+	return parent, parentEdge, tree.dist
+}
+
+// treeCycle closes the tree paths from x and y up to their common ancestor
+// top with the edge between x and y.
+func treeCycle(graph model.Graph, adj adjacency, parent, parentEdge []int, top, x, y, edge int) Cycle {
+	var nodes, edges []int
+	for v := x; v != top; v = parent[v] {
+		nodes = append(nodes, v)
+		edges = append(edges, parentEdge[v])
+	}
+	nodes = append(nodes, top)
+	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+	for i, j := 0, len(edges)-1; i < j; i, j = i+1, j-1 {
+		edges[i], edges[j] = edges[j], edges[i]
+	}
+	edges = append(edges, edge)
+	for v := y; v != top; v = parent[v] {
+		nodes = append(nodes, v)
+		edges = append(edges, parentEdge[v])
+	}
+
+	var c Cycle
+	for _, v := range nodes {
+		c.Nodes = append(c.Nodes, adj.nodes[v].ID)
+	}
+	for _, e := range edges {
+		c.Edges = append(c.Edges, graph.Edges[e].ID)
+		c.Weight += edgeWeight(graph.Edges[e], adj.weighted)
+	}
+	return c
+}
+
+// fundamentalCycles closes every edge left out of a BFS spanning forest
+// with the tree path between its endpoints.
+func fundamentalCycles(graph model.Graph, adj adjacency) []Cycle {
+	n := len(adj.nodes)
+	parent := make([]int, n)
+	parentEdge := make([]int, n)
+	depth := make([]int, n)
+	visited := make([]bool, n)
+	hops := adj.unweighted()
+	for root := range adj.nodes {
+		if visited[root] {
+			continue
+		}
+		p, pe, dist := hops.pathTree(root)
+		for v := range adj.nodes {
+			if !math.IsInf(dist[v], 1) {
+				visited[v] = true
+				parent[v], parentEdge[v], depth[v] = p[v], pe[v], int(dist[v])
+			}
+		}
+	}
+
+	inTree := make([]bool, len(graph.Edges))
+	for _, e := range parentEdge {
+		if e != -1 {
+			inTree[e] = true
+		}
+	}
+	var res []Cycle
+	for i, e := range graph.Edges {
+		if inTree[i] {
+			continue
+		}
+		x, y := adj.index[e.From.ID], adj.index[e.To.ID]
+		top, other := x, y
+		for top != other {
+			if depth[top] < depth[other] {
+				top, other = other, top
+			}
+			top = parent[top]
+		}
+		res = append(res, treeCycle(graph, adj, parent, parentEdge, top, x, y, i))
+	}
+	return res
+}
+
+// minimumCycleBasis is Horton's algorithm: every edge closed with shortest
+// paths from every node to its endpoints makes a candidate cycle, and the
+// lightest candidates independent over GF(2) form the basis.
+func minimumCycleBasis(graph model.Graph, adj adjacency) []Cycle {
+	rank := cycleRank(graph, adj)
+	if rank == 0 {
+		return nil
+	}
+	type tree struct {
+		parent, parentEdge []int
+		dist               []float64
+	}
+	trees := make([]tree, len(adj.nodes))
+	type candidate struct {
+		root, edge int
+		weight     float64
+	}
+	var candidates []candidate
+	onPath := make([]int, len(adj.nodes))
+	for v := range adj.nodes {
+		p, pe, dist := adj.pathTree(v)
+		trees[v] = tree{parent: p, parentEdge: pe, dist: dist}
+		for i := range onPath {
+			onPath[i] = -1
+		}
+		for i, e := range graph.Edges {
+			x, y := adj.index[e.From.ID], adj.index[e.To.ID]
+			if math.IsInf(dist[x], 1) || math.IsInf(dist[y], 1) || pe[x] == i || pe[y] == i {
+				continue
+			}
+			// Paths from both endpoints must meet at v only.
+			for u := x; u != v; u = p[u] {
+				onPath[u] = i
+			}
+			simple := true
+			for u := y; u != v; u = p[u] {
+				if onPath[u] == i {
+					simple = false
+					break
+				}
+			}
+			if simple {
+				candidates = append(candidates, candidate{
+					root:   v,
+					edge:   i,
+					weight: dist[x] + dist[y] + edgeWeight(e, adj.weighted),
+				})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].weight < candidates[j].weight
+	})
+
+	basis := newCycleSpace()
+	var res []Cycle
+	for _, c := range candidates {
+		t := trees[c.root]
+		e := graph.Edges[c.edge]
+		cycle := treeCycle(graph, adj, t.parent, t.parentEdge, c.root, adj.index[e.From.ID], adj.index[e.To.ID], c.edge)
+		set := newEdgeSet(len(graph.Edges))
+		for u := adj.index[e.From.ID]; u != c.root; u = t.parent[u] {
+			set.flip(t.parentEdge[u])
+		}
+		for u := adj.index[e.To.ID]; u != c.root; u = t.parent[u] {
+			set.flip(t.parentEdge[u])
+		}
+		set.flip(c.edge)
+		if basis.add(set) {
+			res = append(res, cycle)
+			if len(res) == rank {
+				break
+			}
+		}
+	}
+	return res
+}
+
+// edgeSet is a subset of edges, a vector over GF(2).
+type edgeSet []uint64
+
+func newEdgeSet(edges int) edgeSet {
+	return make(edgeSet, (edges+63)/64)
+}
+
+func (s edgeSet) flip(edge int) {
+	s[edge/64] ^= 1 << uint(edge%64)
+}
+
+// highest returns the largest edge of the set, or -1 for an empty set.
+func (s edgeSet) highest() int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] != 0 {
+			return i*64 + bits.Len64(s[i]) - 1
+		}
+	}
+	return -1
+}
+
+// cycleSpace keeps independent edge sets keyed by their highest edge.
+type cycleSpace map[int]edgeSet
+
+func newCycleSpace() cycleSpace {
+	return make(cycleSpace)
+}
+
+// add reduces the set by the ones already kept and keeps it if anything is
+// left, reporting whether it was independent.
+func (c cycleSpace) add(set edgeSet) bool {
+	for {
+		h := set.highest()
+		if h == -1 {
+			return false
+		}
+		row, ok := c[h]
+		if !ok {
+			c[h] = set
+			return true
+		}
+		for i := range set {
+			set[i] ^= row[i]
+		}
+	}
+}
+
+// simpleCycles is Johnson's algorithm. Graphs without directed edges are
+// treated as undirected, so every cycle is listed once and a cycle of two
+// nodes needs parallel edges; otherwise an undirected edge is a pair of
+// opposite arcs, which still can't make a cycle with itself.
+func simpleCycles(graph model.Graph, limit uint64) [][]uint64 {
+	adj := newAdjacency(graph)
+	s := cycleSearch{
+		adj:        adj,
+		undirected: !hasDirectedEdges(graph),
+		limit:      limit,
+		succ:       make([][]int, len(adj.nodes)),
+		blocked:    make([]bool, len(adj.nodes)),
+		blockedBy:  make([]map[int]struct{}, len(adj.nodes)),
+	}
+	for v := range adj.nodes {
+		seen := make(map[int]struct{})
+		for _, w := range adj.out[v] {
+			if _, ok := seen[w.to]; !ok {
+				seen[w.to] = struct{}{}
+				s.succ[v] = append(s.succ[v], w.to)
+			}
+		}
+		sort.Ints(s.succ[v])
+	}
+	for start := range adj.nodes {
+		if s.stopped {
+			break
+		}
+		s.start = start
+		for v := start; v < len(adj.nodes); v++ {
+			s.blocked[v] = false
+			s.blockedBy[v] = make(map[int]struct{})
+		}
+		s.circuit(start)
+	}
+	return s.cycles
+}
+
+type cycleSearch struct {
+	adj        adjacency
+	undirected bool
+	limit      uint64
+	succ       [][]int
+	start      int
+	stack      []int
+	blocked    []bool
+	blockedBy  []map[int]struct{}
+	cycles     [][]uint64
+	stopped    bool
+}
+
+func (s *cycleSearch) circuit(v int) bool {
+	found := false
+	s.stack = append(s.stack, v)
+	s.blocked[v] = true
+	for _, w := range s.succ[v] {
+		if s.stopped {
+			break
+		}
+		if w < s.start {
+			continue
+		}
+		if w == s.start {
+			s.report()
+			found = true
+		} else if !s.blocked[w] && s.circuit(w) {
+			found = true
+		}
+	}
+	if found {
+		s.unblock(v)
+	} else {
+		for _, w := range s.succ[v] {
+			if w >= s.start {
+				s.blockedBy[w][v] = struct{}{}
+			}
+		}
+	}
+	s.stack = s.stack[:len(s.stack)-1]
+	return found
+}
+
+func (s *cycleSearch) unblock(v int) {
+	s.blocked[v] = false
+	for w := range s.blockedBy[v] {
+		delete(s.blockedBy[v], w)
+		if s.blocked[w] {
+			s.unblock(w)
+		}
+	}
+}
+
+func (s *cycleSearch) report() {
+	last := len(s.stack) - 1
+	switch {
+	case last == 1 && !s.distinctArcs(s.stack[0], s.stack[1]):
+		return
+	case last > 1 && s.undirected && s.stack[1] > s.stack[last]:
+		return
+	}
+	cycle := make([]uint64, len(s.stack))
+	for i, v := range s.stack {
+		cycle[i] = s.adj.nodes[v].ID
+	}
+	s.cycles = append(s.cycles, cycle)
+	if s.limit != 0 && uint64(len(s.cycles)) >= s.limit {
+		s.stopped = true
+	}
+}
+
+// distinctArcs reports whether u and v can be left towards each other
+// along two different edges.
+func (s *cycleSearch) distinctArcs(u, v int) bool {
+	for _, a := range s.adj.out[u] {
+		if a.to != v {
+			continue
+		}
+		for _, b := range s.adj.out[v] {
+			if b.to == u && b.edge != a.edge {
+				return true
+			}
+		}
+	}
+	return false
+}
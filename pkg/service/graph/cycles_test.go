@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_CycleBases(t *testing.T) {
+	// Two squares sharing the edge 2-5, with a chord 1-5 in the left one.
+	graph := edgesGraph(false,
+		[2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 6}, [2]uint64{6, 5},
+		[2]uint64{5, 4}, [2]uint64{4, 1}, [2]uint64{2, 5}, [2]uint64{1, 5})
+
+	got, err := Graph{}.Cycles(graph, false, 0)
+	require.NoError(t, err)
+	assert.Len(t, got.FundamentalBasis, 3)
+	for _, c := range got.FundamentalBasis {
+		assertClosedWalk(t, graph, c)
+	}
+	require.Len(t, got.MinimumBasis, 3)
+	for _, c := range got.MinimumBasis {
+		assertClosedWalk(t, graph, c)
+	}
+	assert.Equal(t, 10.0, got.MinimumBasisWeight)
+	assert.Equal(t, uint64(3), got.Girth)
+	assert.Nil(t, got.SimpleCycles)
+}
+
+func TestGraph_MinimumCycleBasisWeighted(t *testing.T) {
+	graph := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 4}, [2]uint64{4, 1}, [2]uint64{1, 3})
+	for i, w := range []float64{1, 1, 1, 1, 5} {
+		graph.Edges[i].Weight = w
+	}
+
+	got, err := Graph{}.Cycles(graph, false, 0)
+	require.NoError(t, err)
+	require.Len(t, got.MinimumBasis, 2)
+	assert.Equal(t, 4.0, got.MinimumBasis[0].Weight)
+	assert.Equal(t, 7.0, got.MinimumBasis[1].Weight)
+	assert.Equal(t, 11.0, got.MinimumBasisWeight)
+
+	graph.Edges[4].Weight = -1
+	_, err = Graph{}.Cycles(graph, false, 0)
+	assert.Equal(t, ErrNegativeWeight, err)
+}
+
+func TestGraph_CyclesLoopsAndParallelEdges(t *testing.T) {
+	graph := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 1}, [2]uint64{2, 2}, [2]uint64{2, 3})
+
+	got, err := Graph{}.Cycles(graph, true, 0)
+	require.NoError(t, err)
+	assert.Len(t, got.FundamentalBasis, 2)
+	assert.Len(t, got.MinimumBasis, 2)
+	assert.Equal(t, 3.0, got.MinimumBasisWeight)
+	assert.Equal(t, uint64(1), got.Girth)
+	assert.Equal(t, [][]uint64{{1, 2}, {2}}, got.SimpleCycles)
+}
+
+func TestGraph_SimpleCycles(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph model.Graph
+		limit uint64
+		want  [][]uint64
+	}{
+		{
+			name:  "undirected",
+			graph: edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 4}, [2]uint64{4, 1}, [2]uint64{1, 3}),
+			want:  [][]uint64{{1, 2, 3}, {1, 2, 3, 4}, {1, 3, 4}},
+		},
+		{
+			name: "directed",
+			graph: edgesGraph(true, [2]uint64{1, 2}, [2]uint64{2, 1}, [2]uint64{2, 3}, [2]uint64{3, 1},
+				[2]uint64{3, 4}),
+			want: [][]uint64{{1, 2}, {1, 2, 3}},
+		},
+		{
+			name:  "limit",
+			graph: edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 4}, [2]uint64{4, 1}, [2]uint64{1, 3}),
+			limit: 2,
+			want:  [][]uint64{{1, 2, 3}, {1, 2, 3, 4}},
+		},
+		{
+			name:  "acyclic",
+			graph: edgesGraph(true, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{1, 3}),
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Graph{}.Cycles(tt.graph, true, tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.SimpleCycles)
+		})
+	}
+}
+
+// assertClosedWalk checks that consecutive nodes of the cycle are joined by
+// its edges.
+func assertClosedWalk(t *testing.T, graph model.Graph, c Cycle) {
+	t.Helper()
+	require.Len(t, c.Edges, len(c.Nodes))
+	byID := make(map[uint64]model.Edge)
+	for _, e := range graph.Edges {
+		byID[e.ID] = e
+	}
+	for i, id := range c.Edges {
+		e := byID[id]
+		from, to := c.Nodes[i], c.Nodes[(i+1)%len(c.Nodes)]
+		assert.True(t, e.From.ID == from && e.To.ID == to || e.From.ID == to && e.To.ID == from,
+			"edge %d doesn't join %d and %d", id, from, to)
+	}
+}
@@ -0,0 +1,201 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+var (
+	ErrInvalidSequence = errors.New("out and in degree sequences differ in length")
+	ErrLongSequence    = errors.New("degree sequence is longer than the node limit")
+)
+
+type InequalityKind string
+
+const (
+	// EvenSumInequality requires the sum of degrees to be even; Left is the
+	// sum and Right is unused.
+	EvenSumInequality InequalityKind = "evenSum"
+	// EqualSumsInequality requires out degrees and in degrees to add up to
+	// the same number, Left and Right.
+	EqualSumsInequality InequalityKind = "equalSums"
+	// ErdosGallaiInequality requires the k largest degrees to add up to at
+	// most k(k-1) plus the sum of min(d, k) over the remaining degrees.
+	ErdosGallaiInequality InequalityKind = "erdosGallai"
+	// FulkersonChenAnsteeInequality requires the k largest out degrees,
+	// ordered lexicographically with in degrees, to add up to at most the
+	// sum of min(in, k-1) over their in degrees plus the sum of min(in, k)
+	// over the remaining ones.
+	FulkersonChenAnsteeInequality InequalityKind = "fulkersonChenAnstee"
+)
+
+// Inequality is a violated condition of a graphical sequence, where Left
+// should have been at most, or for sums equal to, Right.
+type Inequality struct {
+	Kind  InequalityKind `json:"kind"`
+	K     uint64         `json:"k"`
+	Left  uint64         `json:"left"`
+	Right uint64         `json:"right"`
+}
+
+// GraphicalCheck tells whether a degree sequence is realised by a simple
+// graph, and if not, the first inequality it fails.
+type GraphicalCheck struct {
+	Graphical bool        `json:"graphical"`
+	Failure   *Inequality `json:"failure,omitempty"`
+}
+
+// RealizeDegrees checks the sequence with the Erdős–Gallai theorem and, if
+// it's graphical, builds an undirected simple graph where node i+1 has
+// degree degrees[i] with the Havel–Hakimi algorithm. Nodes are placed on a
+// circle. Sequences are limited to the size of generated graphs.
+func (g Graph) RealizeDegrees(name string, degrees []uint64) (model.Graph, GraphicalCheck, error) {
+	if len(degrees) > maxGeneratedNodes {
+		return model.Graph{}, GraphicalCheck{}, ErrLongSequence
+	}
+	check := erdosGallai(degrees)
+	if !check.Graphical {
+		return model.Graph{}, check, nil
+	}
+	b := newBuilder(name)
+	nodes := b.addNumberedNodes(len(degrees))
+	left := append([]uint64(nil), degrees...)
+	order := make([]int, len(degrees))
+	for i := range order {
+		order[i] = i
+	}
+	for len(order) > 0 {
+		sort.SliceStable(order, func(i, j int) bool {
+			return left[order[i]] > left[order[j]]
+		})
+		v := order[0]
+		if left[v] == 0 {
+			break
+		}
+		for _, u := range order[1 : left[v]+1] {
+			b.connect(nodes[v], nodes[u], false)
+			left[u]--
+		}
+		left[v] = 0
+	}
+	return circularLayout(b.result()), check, nil
+}
+
+// RealizeDirectedDegrees checks the pair of sequences with the
+// Fulkerson–Chen–Anstee theorem and, if they're graphical, builds a
+// directed simple graph where node i+1 has out degree out[i] and in degree
+// in[i] with the Kleitman–Wang algorithm. Nodes are placed on a circle.
+// Sequences are limited to the size of generated graphs.
+func (g Graph) RealizeDirectedDegrees(name string, out, in []uint64) (model.Graph, GraphicalCheck, error) {
+	if len(out) != len(in) {
+		return model.Graph{}, GraphicalCheck{}, ErrInvalidSequence
+	}
+	if len(out) > maxGeneratedNodes {
+		return model.Graph{}, GraphicalCheck{}, ErrLongSequence
+	}
+	check := fulkersonChenAnstee(out, in)
+	if !check.Graphical {
+		return model.Graph{}, check, nil
+	}
+	b := newBuilder(name)
+	nodes := b.addNumberedNodes(len(out))
+	leftOut := append([]uint64(nil), out...)
+	leftIn := append([]uint64(nil), in...)
+	for {
+		v := -1
+		for u := range leftOut {
+			if leftOut[u] > 0 && (v == -1 || leftOut[u] > leftOut[v]) {
+				v = u
+			}
+		}
+		if v == -1 {
+			break
+		}
+		// Targets are the nodes missing most incoming edges, preferring
+		// the ones with more outgoing edges still to add.
+		targets := make([]int, 0, len(out)-1)
+		for u := range leftIn {
+			if u != v {
+				targets = append(targets, u)
+			}
+		}
+		sort.SliceStable(targets, func(i, j int) bool {
+			a, b := targets[i], targets[j]
+			return leftIn[a] > leftIn[b] || leftIn[a] == leftIn[b] && leftOut[a] > leftOut[b]
+		})
+		for _, u := range targets[:leftOut[v]] {
+			b.connect(nodes[v], nodes[u], true)
+			leftIn[u]--
+		}
+		leftOut[v] = 0
+	}
+	return circularLayout(b.result()), check, nil
+}
+
+func erdosGallai(degrees []uint64) GraphicalCheck {
+	d := append([]uint64(nil), degrees...)
+	sort.Slice(d, func(i, j int) bool { return d[i] > d[j] })
+	var total uint64
+	for _, x := range d {
+		total += x
+	}
+	if total%2 != 0 {
+		return GraphicalCheck{Failure: &Inequality{Kind: EvenSumInequality, Left: total}}
+	}
+	var left uint64
+	for k := 1; k <= len(d); k++ {
+		left += d[k-1]
+		right := uint64(k * (k - 1))
+		for _, x := range d[k:] {
+			right += minUint(x, uint64(k))
+		}
+		if left > right {
+			return GraphicalCheck{Failure: &Inequality{Kind: ErdosGallaiInequality, K: uint64(k), Left: left, Right: right}}
+		}
+	}
+	return GraphicalCheck{Graphical: true}
+}
+
+func fulkersonChenAnstee(out, in []uint64) GraphicalCheck {
+	type pair struct {
+		out, in uint64
+	}
+	pairs := make([]pair, len(out))
+	var outSum, inSum uint64
+	for i := range out {
+		pairs[i] = pair{out: out[i], in: in[i]}
+		outSum += out[i]
+		inSum += in[i]
+	}
+	if outSum != inSum {
+		return GraphicalCheck{Failure: &Inequality{Kind: EqualSumsInequality, Left: outSum, Right: inSum}}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].out > pairs[j].out || pairs[i].out == pairs[j].out && pairs[i].in > pairs[j].in
+	})
+	var left uint64
+	for k := 1; k <= len(pairs); k++ {
+		left += pairs[k-1].out
+		var right uint64
+		for i, p := range pairs {
+			if i < k {
+				right += minUint(p.in, uint64(k-1))
+			} else {
+				right += minUint(p.in, uint64(k))
+			}
+		}
+		if left > right {
+			return GraphicalCheck{Failure: &Inequality{Kind: FulkersonChenAnsteeInequality, K: uint64(k), Left: left, Right: right}}
+		}
+	}
+	return GraphicalCheck{Graphical: true}
+}
+
+func minUint(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
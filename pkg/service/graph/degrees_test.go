@@ -0,0 +1,147 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_RealizeDegrees(t *testing.T) {
+	tests := []struct {
+		name    string
+		degrees []uint64
+		want    GraphicalCheck
+	}{
+		{
+			name:    "graphical",
+			degrees: []uint64{3, 3, 2, 2, 2, 1, 1},
+			want:    GraphicalCheck{Graphical: true},
+		},
+		{
+			name:    "complete",
+			degrees: []uint64{3, 3, 3, 3},
+			want:    GraphicalCheck{Graphical: true},
+		},
+		{
+			name:    "empty",
+			degrees: []uint64{},
+			want:    GraphicalCheck{Graphical: true},
+		},
+		{
+			name:    "odd sum",
+			degrees: []uint64{2, 2, 1},
+			want:    GraphicalCheck{Failure: &Inequality{Kind: EvenSumInequality, Left: 5}},
+		},
+		{
+			name:    "too few small degrees",
+			degrees: []uint64{3, 1, 3, 3},
+			want:    GraphicalCheck{Failure: &Inequality{Kind: ErdosGallaiInequality, K: 2, Left: 6, Right: 5}},
+		},
+		{
+			name:    "degree above node count",
+			degrees: []uint64{4, 2, 2},
+			want:    GraphicalCheck{Failure: &Inequality{Kind: ErdosGallaiInequality, K: 1, Left: 4, Right: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph, check, err := Graph{}.RealizeDegrees("sequence", tt.degrees)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, check)
+			if !check.Graphical {
+				return
+			}
+			assert.Equal(t, "sequence", graph.Name)
+			assert.Len(t, graph.Nodes, len(tt.degrees))
+			stats := Graph{}.Stats(graph)
+			assert.Zero(t, stats.SelfLoops)
+			assert.Zero(t, stats.ParallelEdges)
+			assert.Equal(t, tt.degrees, realizedDegrees(graph, func(e model.Edge) []uint64 {
+				return []uint64{e.From.ID, e.To.ID}
+			}))
+		})
+	}
+}
+
+func TestGraph_RealizeDegreesNil(t *testing.T) {
+	graph, check, err := Graph{}.RealizeDegrees("", nil)
+	require.NoError(t, err)
+	assert.True(t, check.Graphical)
+	assert.Empty(t, graph.Nodes)
+
+	_, _, err = Graph{}.RealizeDegrees("", make([]uint64, maxGeneratedNodes+1))
+	assert.Equal(t, ErrLongSequence, err)
+}
+
+func TestGraph_RealizeDirectedDegrees(t *testing.T) {
+	tests := []struct {
+		name    string
+		out, in []uint64
+		want    GraphicalCheck
+	}{
+		{
+			name: "graphical",
+			out:  []uint64{2, 1, 1, 0},
+			in:   []uint64{0, 1, 1, 2},
+			want: GraphicalCheck{Graphical: true},
+		},
+		{
+			name: "tournament",
+			out:  []uint64{1, 1, 1},
+			in:   []uint64{1, 1, 1},
+			want: GraphicalCheck{Graphical: true},
+		},
+		{
+			name: "different sums",
+			out:  []uint64{1, 1},
+			in:   []uint64{1, 0},
+			want: GraphicalCheck{Failure: &Inequality{Kind: EqualSumsInequality, Left: 2, Right: 1}},
+		},
+		{
+			name: "loop needed",
+			out:  []uint64{1, 0},
+			in:   []uint64{1, 0},
+			want: GraphicalCheck{Failure: &Inequality{Kind: FulkersonChenAnsteeInequality, K: 1, Left: 1, Right: 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph, check, err := Graph{}.RealizeDirectedDegrees("", tt.out, tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, check)
+			if !check.Graphical {
+				return
+			}
+			stats := Graph{}.Stats(graph)
+			assert.Zero(t, stats.SelfLoops)
+			assert.Zero(t, stats.ParallelEdges)
+			assert.Equal(t, tt.out, realizedDegrees(graph, func(e model.Edge) []uint64 {
+				return []uint64{e.From.ID}
+			}))
+			assert.Equal(t, tt.in, realizedDegrees(graph, func(e model.Edge) []uint64 {
+				return []uint64{e.To.ID}
+			}))
+		})
+	}
+
+	_, _, err := Graph{}.RealizeDirectedDegrees("", []uint64{1}, nil)
+	assert.Equal(t, ErrInvalidSequence, err)
+	long := make([]uint64, maxGeneratedNodes+1)
+	_, _, err = Graph{}.RealizeDirectedDegrees("", long, long)
+	assert.Equal(t, ErrLongSequence, err)
+}
+
+// realizedDegrees counts for every node, in order of IDs, the edges for
+// which ends lists it.
+func realizedDegrees(graph model.Graph, ends func(model.Edge) []uint64) []uint64 {
+	degrees := make([]uint64, len(graph.Nodes))
+	for _, e := range graph.Edges {
+		for _, id := range ends(e) {
+			degrees[id-1]++
+		}
+	}
+	return degrees
+}
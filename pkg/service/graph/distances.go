@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"container/heap"
+	"math"
+)
+
+// shortestPathTree is the result of a single source search. Unreachable
+// nodes have an infinite distance, sigma counts shortest paths and order
+// lists reached nodes by non-decreasing distance.
+type shortestPathTree struct {
+	dist  []float64
+	sigma []float64
+	preds [][]int
+	order []int
+}
+
+// shortestPaths runs BFS on unweighted views and Dijkstra otherwise. Callers
+// reject negative edge weights with checkWeights first.
+func (a adjacency) shortestPaths(source int) shortestPathTree {
+	tree := shortestPathTree{
+		dist:  make([]float64, len(a.nodes)),
+		sigma: make([]float64, len(a.nodes)),
+		preds: make([][]int, len(a.nodes)),
+	}
+	for i := range tree.dist {
+		tree.dist[i] = math.Inf(1)
+	}
+	tree.dist[source] = 0
+	tree.sigma[source] = 1
+	if a.weighted {
+		a.dijkstra(source, &tree)
+	} else {
+		a.bfs(source, &tree)
+	}
+	return tree
+}
+
+func (a adjacency) bfs(source int, tree *shortestPathTree) {
+	queue := []int{source}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		tree.order = append(tree.order, v)
+		for _, w := range a.out[v] {
+			if math.IsInf(tree.dist[w.to], 1) {
+				tree.dist[w.to] = tree.dist[v] + 1
+				queue = append(queue, w.to)
+			}
+			if tree.dist[w.to] == tree.dist[v]+1 {
+				tree.sigma[w.to] += tree.sigma[v]
+				tree.preds[w.to] = append(tree.preds[w.to], v)
+			}
+		}
+	}
+}
+
+func (a adjacency) dijkstra(source int, tree *shortestPathTree) {
+	done := make([]bool, len(a.nodes))
+	queue := &distHeap{{node: source}}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(distItem)
+		v := item.node
+		if done[v] {
+			continue
+		}
+		done[v] = true
+		tree.order = append(tree.order, v)
+		for _, w := range a.out[v] {
+			if done[w.to] {
+				continue
+			}
+			d := tree.dist[v] + w.weight
+			switch {
+			case sameDistance(d, tree.dist[w.to]):
+				tree.sigma[w.to] += tree.sigma[v]
+				tree.preds[w.to] = append(tree.preds[w.to], v)
+			case d < tree.dist[w.to]:
+				tree.dist[w.to] = d
+				tree.sigma[w.to] = tree.sigma[v]
+				tree.preds[w.to] = []int{v}
+				heap.Push(queue, distItem{node: w.to, dist: d})
+			}
+		}
+	}
+}
+
+// allDistances returns the matrix of shortest path distances.
+func (a adjacency) allDistances() [][]float64 {
+	dist := make([][]float64, len(a.nodes))
+	for v := range a.nodes {
+		dist[v] = a.shortestPaths(v).dist
+	}
+	return dist
+}
+
+func sameDistance(a, b float64) bool {
+	if math.IsInf(b, 1) {
+		return false
+	}
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+}
+
+type distItem struct {
+	node int
+	dist float64
+}
+
+type distHeap []distItem
+
+func (h distHeap) Len() int            { return len(h) }
+func (h distHeap) Less(i, j int) bool  { return h[i].dist < h[j].dist }
+func (h distHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *distHeap) Push(x interface{}) { *h = append(*h, x.(distItem)) }
+
+func (h *distHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,143 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+type Dominators struct {
+	Tree                model.Graph         `json:"tree"`
+	ImmediateDominators map[uint64]uint64   `json:"immediateDominators"`
+	Frontier            map[uint64][]uint64 `json:"frontier"`
+}
+
+// Dominators computes immediate dominators of nodes reachable from the
+// root with the iterative algorithm of Cooper, Harvey and Kennedy. The
+// dominator tree keeps node IDs and attributes and has an edge from every
+// immediate dominator to the nodes it dominates. Undirected edges may be
+// walked both ways.
+func (g Graph) Dominators(graph model.Graph, root uint64) (Dominators, error) {
+	adj := newAdjacency(graph)
+	r, ok := adj.index[root]
+	if !ok {
+		return Dominators{}, ErrNodeNotFound
+	}
+
+	// Postorder numbers of reachable nodes, unreachable ones keep -1.
+	postorder := make([]int, len(adj.nodes))
+	for i := range postorder {
+		postorder[i] = -1
+	}
+	var order []int
+	visited := make([]bool, len(adj.nodes))
+	var dfs func(v int)
+	dfs = func(v int) {
+		visited[v] = true
+		for _, w := range adj.out[v] {
+			if !visited[w.to] {
+				dfs(w.to)
+			}
+		}
+		postorder[v] = len(order)
+		order = append(order, v)
+	}
+	dfs(r)
+
+	idom := make([]int, len(adj.nodes))
+	for i := range idom {
+		idom[i] = -1
+	}
+	idom[r] = r
+	intersect := func(a, b int) int {
+		for a != b {
+			for postorder[a] < postorder[b] {
+				a = idom[a]
+			}
+			for postorder[b] < postorder[a] {
+				b = idom[b]
+			}
+		}
+		return a
+	}
+	for changed := true; changed; {
+		changed = false
+		for i := len(order) - 2; i >= 0; i-- {
+			v := order[i]
+			newIdom := -1
+			for _, p := range adj.in[v] {
+				if idom[p.to] == -1 {
+					continue
+				}
+				if newIdom == -1 {
+					newIdom = p.to
+				} else {
+					newIdom = intersect(p.to, newIdom)
+				}
+			}
+			if idom[v] != newIdom {
+				idom[v] = newIdom
+				changed = true
+			}
+		}
+	}
+
+	res := Dominators{
+		Tree:                model.Graph{Name: graph.Name},
+		ImmediateDominators: make(map[uint64]uint64, len(order)-1),
+		Frontier:            make(map[uint64][]uint64, len(order)),
+	}
+	for v, n := range adj.nodes {
+		if postorder[v] != -1 {
+			res.Tree.Nodes = append(res.Tree.Nodes, n)
+		}
+	}
+	for i := len(order) - 2; i >= 0; i-- {
+		v := order[i]
+		res.ImmediateDominators[adj.nodes[v].ID] = adj.nodes[idom[v]].ID
+		res.Tree.Edges = append(res.Tree.Edges, model.Edge{
+			ID:         uint64(len(res.Tree.Edges) + 1),
+			From:       adj.nodes[idom[v]],
+			To:         adj.nodes[v],
+			IsDirected: true,
+		})
+	}
+
+	frontier := make([]map[int]struct{}, len(adj.nodes))
+	for _, v := range order {
+		frontier[v] = make(map[int]struct{})
+	}
+	// Only joins and the root, which has no dominator above it to stop at,
+	// can be in frontiers.
+	for _, v := range order {
+		if len(adj.in[v]) < 2 && v != r {
+			continue
+		}
+		stop := idom[v]
+		if v == r {
+			stop = -1
+		}
+		for _, p := range adj.in[v] {
+			if postorder[p.to] == -1 {
+				continue
+			}
+			for runner := p.to; runner != stop; runner = idom[runner] {
+				frontier[runner][v] = struct{}{}
+				if runner == r {
+					break
+				}
+			}
+		}
+	}
+	for _, v := range order {
+		ids := make([]uint64, 0, len(frontier[v]))
+		for u := range frontier[v] {
+			ids = append(ids, adj.nodes[u].ID)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+		res.Frontier[adj.nodes[v].ID] = ids
+	}
+	return res, nil
+}
@@ -0,0 +1,40 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraph_Dominators(t *testing.T) {
+	// A loop 2..5 with a branch inside, exiting to 6. Node 7 is unreachable.
+	graph := edgesGraph(true,
+		[2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{2, 4}, [2]uint64{3, 5},
+		[2]uint64{4, 5}, [2]uint64{5, 2}, [2]uint64{5, 6}, [2]uint64{7, 6},
+	)
+
+	got, err := Graph{}.Dominators(graph, 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]uint64{2: 1, 3: 2, 4: 2, 5: 2, 6: 5}, got.ImmediateDominators)
+	assert.Equal(t, map[uint64][]uint64{
+		1: {},
+		2: {2},
+		3: {5},
+		4: {5},
+		5: {2},
+		6: {},
+	}, got.Frontier)
+	assert.Len(t, got.Tree.Nodes, 6)
+	assert.ElementsMatch(t, [][2]uint64{{1, 2}, {2, 3}, {2, 4}, {2, 5}, {5, 6}}, edgePairs(got.Tree))
+
+	_, err = Graph{}.Dominators(graph, 42)
+	assert.Equal(t, ErrNodeNotFound, err)
+}
+
+func TestGraph_DominatorsFrontierOfRoot(t *testing.T) {
+	got, err := Graph{}.Dominators(edgesGraph(true, [2]uint64{1, 2}, [2]uint64{2, 1}), 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]uint64{2: 1}, got.ImmediateDominators)
+	assert.Equal(t, map[uint64][]uint64{1: {1}, 2: {1}}, got.Frontier)
+}
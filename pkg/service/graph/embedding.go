@@ -0,0 +1,447 @@
+package graph
+
+import (
+	"sort"
+)
+
+// embedding is a combinatorial embedding of a simple undirected graph: the
+// clockwise order of neighbours around every node, kept as a circular list.
+type embedding struct {
+	cw    []map[int]int
+	ccw   []map[int]int
+	first []int
+}
+
+func newEmbedding(n int) embedding {
+	e := embedding{
+		cw:    make([]map[int]int, n),
+		ccw:   make([]map[int]int, n),
+		first: make([]int, n),
+	}
+	for v := 0; v < n; v++ {
+		e.cw[v] = make(map[int]int)
+		e.ccw[v] = make(map[int]int)
+		e.first[v] = -1
+	}
+	return e
+}
+
+func (e embedding) hasEdge(v, w int) bool {
+	_, ok := e.cw[v][w]
+	return ok
+}
+
+// addCW puts w right after ref clockwise around v. Without ref, -1, w
+// becomes the only neighbour of v.
+func (e embedding) addCW(v, w, ref int) {
+	if ref == -1 {
+		e.cw[v][w], e.ccw[v][w] = w, w
+		e.first[v] = w
+		return
+	}
+	next := e.cw[v][ref]
+	e.cw[v][ref] = w
+	e.cw[v][w] = next
+	e.ccw[v][next] = w
+	e.ccw[v][w] = ref
+}
+
+// addCCW puts w right before ref clockwise around v, taking its place as
+// the first neighbour.
+func (e embedding) addCCW(v, w, ref int) {
+	if ref == -1 {
+		e.addCW(v, w, -1)
+		return
+	}
+	e.addCW(v, w, e.ccw[v][ref])
+	if e.first[v] == ref {
+		e.first[v] = w
+	}
+}
+
+// addFirst makes w the first neighbour of v.
+func (e embedding) addFirst(v, w int) {
+	e.addCCW(v, w, e.first[v])
+}
+
+// neighbours lists neighbours of v in clockwise order.
+func (e embedding) neighbours(v int) []int {
+	if e.first[v] == -1 {
+		return nil
+	}
+	res := []int{e.first[v]}
+	for w := e.cw[v][e.first[v]]; w != e.first[v]; w = e.cw[v][w] {
+		res = append(res, w)
+	}
+	return res
+}
+
+// nextFaceHalfEdge follows the face on the left of the half edge from v to
+// w and returns its next half edge.
+func (e embedding) nextFaceHalfEdge(v, w int) (int, int) {
+	return w, e.ccw[w][v]
+}
+
+// halfEdge is an edge oriented by the depth first search of the planarity
+// test.
+type halfEdge [2]int
+
+var noHalfEdge = halfEdge{-1, -1}
+
+type lrInterval struct {
+	low, high halfEdge
+}
+
+var emptyInterval = lrInterval{low: noHalfEdge, high: noHalfEdge}
+
+func (i lrInterval) empty() bool {
+	return i.low == noHalfEdge && i.high == noHalfEdge
+}
+
+type conflictPair struct {
+	left, right lrInterval
+}
+
+func (p *conflictPair) swap() {
+	p.left, p.right = p.right, p.left
+}
+
+// lrPlanarity is the state of the left-right planarity test of Brandes, "The
+// Left-Right Planarity Test", following the criterion of de Fraysseix and
+// Rosenstiehl.
+type lrPlanarity struct {
+	adj        [][]int
+	height     []int
+	parentEdge []halfEdge
+	roots      []int
+	out        [][]int
+	ordered    [][]int
+	oriented   map[halfEdge]bool
+	lowpt      map[halfEdge]int
+	lowpt2     map[halfEdge]int
+	nesting    map[halfEdge]int
+	ref        map[halfEdge]halfEdge
+	side       map[halfEdge]int
+	lowptEdge  map[halfEdge]halfEdge
+	bottom     map[halfEdge]*conflictPair
+	stack      []*conflictPair
+	leftRef    []int
+	rightRef   []int
+}
+
+// planarEmbedding tests whether the simple graph with the given sorted
+// neighbour lists is planar and, if it is, returns an embedding of it.
+func planarEmbedding(adj [][]int) (embedding, bool) {
+	n := len(adj)
+	m := 0
+	for _, ws := range adj {
+		m += len(ws)
+	}
+	m /= 2
+	if n > 2 && m > 3*n-6 {
+		return embedding{}, false
+	}
+	p := &lrPlanarity{
+		adj:        adj,
+		height:     make([]int, n),
+		parentEdge: make([]halfEdge, n),
+		out:        make([][]int, n),
+		ordered:    make([][]int, n),
+		oriented:   make(map[halfEdge]bool, m),
+		lowpt:      make(map[halfEdge]int, m),
+		lowpt2:     make(map[halfEdge]int, m),
+		nesting:    make(map[halfEdge]int, m),
+		ref:        make(map[halfEdge]halfEdge, m),
+		side:       make(map[halfEdge]int, m),
+		lowptEdge:  make(map[halfEdge]halfEdge, m),
+		bottom:     make(map[halfEdge]*conflictPair, m),
+		leftRef:    make([]int, n),
+		rightRef:   make([]int, n),
+	}
+	for v := range p.height {
+		p.height[v] = -1
+		p.parentEdge[v] = noHalfEdge
+	}
+	for v := range adj {
+		if p.height[v] == -1 {
+			p.height[v] = 0
+			p.roots = append(p.roots, v)
+			p.orient(v)
+		}
+	}
+
+	p.sortByNesting()
+	for _, r := range p.roots {
+		if !p.test(r) {
+			return embedding{}, false
+		}
+	}
+	for v, ws := range p.out {
+		for _, w := range ws {
+			e := halfEdge{v, w}
+			p.nesting[e] *= p.sign(e)
+		}
+	}
+	p.sortByNesting()
+
+	emb := newEmbedding(n)
+	for v, ws := range p.ordered {
+		previous := -1
+		for _, w := range ws {
+			emb.addCW(v, w, previous)
+			previous = w
+		}
+	}
+	for _, r := range p.roots {
+		p.embed(emb, r)
+	}
+	return emb, true
+}
+
+func (p *lrPlanarity) sortByNesting() {
+	for v, ws := range p.out {
+		p.ordered[v] = append([]int(nil), ws...)
+		sort.SliceStable(p.ordered[v], func(i, j int) bool {
+			return p.nesting[halfEdge{v, p.ordered[v][i]}] < p.nesting[halfEdge{v, p.ordered[v][j]}]
+		})
+	}
+}
+
+// orient directs edges along a depth first search and computes lowpoints
+// and nesting depths of the edges.
+func (p *lrPlanarity) orient(v int) {
+	e := p.parentEdge[v]
+	for _, w := range p.adj[v] {
+		if p.oriented[halfEdge{v, w}] || p.oriented[halfEdge{w, v}] {
+			continue
+		}
+		vw := halfEdge{v, w}
+		p.oriented[vw] = true
+		p.out[v] = append(p.out[v], w)
+		p.lowpt[vw], p.lowpt2[vw] = p.height[v], p.height[v]
+		if p.height[w] == -1 {
+			p.parentEdge[w] = vw
+			p.height[w] = p.height[v] + 1
+			p.orient(w)
+		} else {
+			p.lowpt[vw] = p.height[w]
+		}
+
+		p.nesting[vw] = 2 * p.lowpt[vw]
+		if p.lowpt2[vw] < p.height[v] {
+			p.nesting[vw]++
+		}
+		if e == noHalfEdge {
+			continue
+		}
+		switch {
+		case p.lowpt[vw] < p.lowpt[e]:
+			p.lowpt2[e] = minInt(p.lowpt[e], p.lowpt2[vw])
+			p.lowpt[e] = p.lowpt[vw]
+		case p.lowpt[vw] > p.lowpt[e]:
+			p.lowpt2[e] = minInt(p.lowpt2[e], p.lowpt[vw])
+		default:
+			p.lowpt2[e] = minInt(p.lowpt2[e], p.lowpt2[vw])
+		}
+	}
+}
+
+func (p *lrPlanarity) top() *conflictPair {
+	if len(p.stack) == 0 {
+		return nil
+	}
+	return p.stack[len(p.stack)-1]
+}
+
+func (p *lrPlanarity) pop() *conflictPair {
+	q := p.stack[len(p.stack)-1]
+	p.stack = p.stack[:len(p.stack)-1]
+	return q
+}
+
+func (p *lrPlanarity) conflicting(i lrInterval, b halfEdge) bool {
+	return !i.empty() && p.lowpt[i.high] > p.lowpt[b]
+}
+
+func (p *lrPlanarity) lowest(q *conflictPair) int {
+	if q.left.empty() {
+		return p.lowpt[q.right.low]
+	}
+	if q.right.empty() {
+		return p.lowpt[q.left.low]
+	}
+	return minInt(p.lowpt[q.left.low], p.lowpt[q.right.low])
+}
+
+func (p *lrPlanarity) refOf(e halfEdge) halfEdge {
+	if r, ok := p.ref[e]; ok {
+		return r
+	}
+	return noHalfEdge
+}
+
+func (p *lrPlanarity) setRef(e, r halfEdge) {
+	if e != noHalfEdge {
+		p.ref[e] = r
+	}
+}
+
+func (p *lrPlanarity) sideOf(e halfEdge) int {
+	if s, ok := p.side[e]; ok {
+		return s
+	}
+	return 1
+}
+
+// sign resolves the side of an edge relative to the edges it refers to.
+func (p *lrPlanarity) sign(e halfEdge) int {
+	if r := p.refOf(e); r != noHalfEdge {
+		p.side[e] = p.sideOf(e) * p.sign(r)
+		p.ref[e] = noHalfEdge
+	}
+	return p.sideOf(e)
+}
+
+// test checks the left-right criterion on the subtree of v.
+func (p *lrPlanarity) test(v int) bool {
+	e := p.parentEdge[v]
+	for _, w := range p.ordered[v] {
+		ei := halfEdge{v, w}
+		p.bottom[ei] = p.top()
+		if ei == p.parentEdge[w] {
+			if !p.test(w) {
+				return false
+			}
+		} else {
+			p.lowptEdge[ei] = ei
+			p.stack = append(p.stack, &conflictPair{left: emptyInterval, right: lrInterval{low: ei, high: ei}})
+		}
+		if p.lowpt[ei] < p.height[v] {
+			if w == p.ordered[v][0] {
+				p.lowptEdge[e] = p.lowptEdge[ei]
+			} else if !p.addConstraints(ei, e) {
+				return false
+			}
+		}
+	}
+	if e != noHalfEdge {
+		p.removeBackEdges(e)
+	}
+	return true
+}
+
+func (p *lrPlanarity) addConstraints(ei, e halfEdge) bool {
+	q := conflictPair{left: emptyInterval, right: emptyInterval}
+	for {
+		r := p.pop()
+		if !r.left.empty() {
+			r.swap()
+		}
+		if !r.left.empty() {
+			return false
+		}
+		if p.lowpt[r.right.low] > p.lowpt[e] {
+			if q.right.empty() {
+				q.right = r.right
+			} else {
+				p.setRef(q.right.low, r.right.high)
+			}
+			q.right.low = r.right.low
+		} else {
+			p.setRef(r.right.low, p.lowptEdge[e])
+		}
+		if p.top() == p.bottom[ei] {
+			break
+		}
+	}
+	for top := p.top(); top != nil && (p.conflicting(top.left, ei) || p.conflicting(top.right, ei)); top = p.top() {
+		r := p.pop()
+		if p.conflicting(r.right, ei) {
+			r.swap()
+		}
+		if p.conflicting(r.right, ei) {
+			return false
+		}
+		p.setRef(q.right.low, r.right.high)
+		if r.right.low != noHalfEdge {
+			q.right.low = r.right.low
+		}
+		if q.left.empty() {
+			q.left = r.left
+		} else {
+			p.setRef(q.left.low, r.left.high)
+		}
+		q.left.low = r.left.low
+	}
+	if !q.left.empty() || !q.right.empty() {
+		p.stack = append(p.stack, &q)
+	}
+	return true
+}
+
+// removeBackEdges drops back edges ending at the parent of e from the
+// conflict pairs and decides the side of e.
+func (p *lrPlanarity) removeBackEdges(e halfEdge) {
+	u := e[0]
+	for len(p.stack) > 0 && p.lowest(p.top()) == p.height[u] {
+		q := p.pop()
+		if q.left.low != noHalfEdge {
+			p.side[q.left.low] = -1
+		}
+	}
+	if len(p.stack) > 0 {
+		q := p.pop()
+		for q.left.high != noHalfEdge && q.left.high[1] == u {
+			q.left.high = p.refOf(q.left.high)
+		}
+		if q.left.high == noHalfEdge && q.left.low != noHalfEdge {
+			p.setRef(q.left.low, q.right.low)
+			p.side[q.left.low] = -1
+			q.left.low = noHalfEdge
+		}
+		for q.right.high != noHalfEdge && q.right.high[1] == u {
+			q.right.high = p.refOf(q.right.high)
+		}
+		if q.right.high == noHalfEdge && q.right.low != noHalfEdge {
+			p.setRef(q.right.low, q.left.low)
+			p.side[q.right.low] = -1
+			q.right.low = noHalfEdge
+		}
+		p.stack = append(p.stack, q)
+	}
+	if p.lowpt[e] < p.height[u] && len(p.stack) > 0 {
+		hl, hr := p.top().left.high, p.top().right.high
+		if hl != noHalfEdge && (hr == noHalfEdge || p.lowpt[hl] > p.lowpt[hr]) {
+			p.setRef(e, hl)
+		} else {
+			p.setRef(e, hr)
+		}
+	}
+}
+
+// embed adds the reverse half edges to the embedding, tree edges first and
+// back edges next to the tree edge they return along, on their side.
+func (p *lrPlanarity) embed(emb embedding, v int) {
+	for _, w := range p.ordered[v] {
+		ei := halfEdge{v, w}
+		if ei == p.parentEdge[w] {
+			emb.addFirst(w, v)
+			p.leftRef[v], p.rightRef[v] = w, w
+			p.embed(emb, w)
+			continue
+		}
+		if p.sideOf(ei) == 1 {
+			emb.addCW(w, v, p.rightRef[w])
+		} else {
+			emb.addCCW(w, v, p.leftRef[w])
+			p.leftRef[w] = v
+		}
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
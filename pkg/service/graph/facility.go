@@ -0,0 +1,259 @@
+package graph
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+const (
+	// exactDominationNodes is the largest graph searched exhaustively for
+	// a minimum dominating set.
+	exactDominationNodes = 32
+	// exactCenterCombinations is the largest number of center sets tried
+	// exhaustively for the k-center problem.
+	exactCenterCombinations = 1 << 16
+)
+
+type DominatingSet struct {
+	Nodes []model.Node `json:"nodes"`
+	Exact bool         `json:"exact"`
+}
+
+type KCenter struct {
+	Centers []model.Node `json:"centers"`
+	Radius  float64      `json:"radius"`
+	Exact   bool         `json:"exact"`
+}
+
+// DominatingSet returns nodes such that every other node is the target of
+// an edge from one of them. It's minimum for graphs of up to
+// exactDominationNodes nodes, larger ones get the greedy approximation
+// which is within a logarithmic factor of it.
+func (g Graph) DominatingSet(graph model.Graph) DominatingSet {
+	adj := newAdjacency(graph)
+	var set []int
+	exact := len(adj.nodes) <= exactDominationNodes
+	if exact {
+		set = exactDominatingSet(adj)
+	} else {
+		set = greedyDominatingSet(adj)
+	}
+	res := DominatingSet{Exact: exact}
+	for _, v := range set {
+		res.Nodes = append(res.Nodes, adj.nodes[v])
+	}
+	return res
+}
+
+// exactDominatingSet branches on the nodes able to dominate the first node
+// left undominated, pruning branches that can't beat the best set found.
+func exactDominatingSet(adj adjacency) []int {
+	n := len(adj.nodes)
+	covers := make([]uint64, n)
+	for v := range adj.nodes {
+		covers[v] = 1 << uint(v)
+		for _, w := range adj.out[v] {
+			covers[v] |= 1 << uint(w.to)
+		}
+	}
+	all := uint64(1)<<uint(n) - 1
+
+	best := make([]int, n)
+	for i := range best {
+		best[i] = i
+	}
+	var current []int
+	var search func(dominated uint64)
+	search = func(dominated uint64) {
+		if dominated == all {
+			if len(current) < len(best) {
+				best = append(best[:0], current...)
+			}
+			return
+		}
+		if len(current)+1 >= len(best) {
+			return
+		}
+		u := bits.TrailingZeros64(^dominated)
+		for v := range adj.nodes {
+			if covers[v]&(1<<uint(u)) == 0 {
+				continue
+			}
+			current = append(current, v)
+			search(dominated | covers[v])
+			current = current[:len(current)-1]
+		}
+	}
+	search(0)
+	sort.Ints(best)
+	return best
+}
+
+// greedyDominatingSet keeps taking the node that dominates most of the
+// nodes not dominated yet.
+func greedyDominatingSet(adj adjacency) []int {
+	dominated := make([]bool, len(adj.nodes))
+	left := len(adj.nodes)
+	var set []int
+	gain := func(v int) int {
+		count := 0
+		if !dominated[v] {
+			count++
+		}
+		seen := make(map[int]struct{})
+		for _, w := range adj.out[v] {
+			if _, ok := seen[w.to]; !ok && w.to != v && !dominated[w.to] {
+				seen[w.to] = struct{}{}
+				count++
+			}
+		}
+		return count
+	}
+	for left > 0 {
+		best, bestGain := -1, 0
+		for v := range adj.nodes {
+			if g := gain(v); g > bestGain {
+				best, bestGain = v, g
+			}
+		}
+		set = append(set, best)
+		if !dominated[best] {
+			dominated[best] = true
+			left--
+		}
+		for _, w := range adj.out[best] {
+			if !dominated[w.to] {
+				dominated[w.to] = true
+				left--
+			}
+		}
+	}
+	sort.Ints(set)
+	return set
+}
+
+// KCenter picks k nodes minimising the largest distance from the nearest
+// of them to any node, following edge directions and weights. All center
+// sets are tried when there are at most exactCenterCombinations of them,
+// otherwise centers are added farthest first starting from a node of
+// minimum eccentricity, which is within twice the optimal radius.
+func (g Graph) KCenter(graph model.Graph, k uint64) (KCenter, error) {
+	if err := checkWeights(graph); err != nil {
+		return KCenter{}, err
+	}
+	adj := newAdjacency(graph)
+	n := len(adj.nodes)
+	if k > uint64(n) {
+		k = uint64(n)
+	}
+	if k == 0 {
+		return KCenter{Exact: true}, nil
+	}
+	dist := adj.allDistances()
+
+	var centers []int
+	exact := combinationsUpTo(n, int(k), exactCenterCombinations) <= exactCenterCombinations
+	if exact {
+		centers = exactKCenter(dist, int(k))
+	} else {
+		centers = greedyKCenter(dist, int(k))
+	}
+	res := KCenter{Radius: coverRadius(dist, centers), Exact: exact}
+	if math.IsInf(res.Radius, 1) {
+		return KCenter{}, ErrNotConnected
+	}
+	for _, v := range centers {
+		res.Centers = append(res.Centers, adj.nodes[v])
+	}
+	return res, nil
+}
+
+// coverRadius is the largest distance from the nearest center to a node.
+func coverRadius(dist [][]float64, centers []int) float64 {
+	var radius float64
+	for v := range dist {
+		nearest := math.Inf(1)
+		for _, c := range centers {
+			nearest = math.Min(nearest, dist[c][v])
+		}
+		radius = math.Max(radius, nearest)
+	}
+	return radius
+}
+
+func exactKCenter(dist [][]float64, k int) []int {
+	n := len(dist)
+	combination := make([]int, k)
+	for i := range combination {
+		combination[i] = i
+	}
+	best := append([]int(nil), combination...)
+	bestRadius := coverRadius(dist, best)
+	for {
+		i := k - 1
+		for i >= 0 && combination[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return best
+		}
+		combination[i]++
+		for j := i + 1; j < k; j++ {
+			combination[j] = combination[j-1] + 1
+		}
+		if radius := coverRadius(dist, combination); radius < bestRadius {
+			best, bestRadius = append(best[:0], combination...), radius
+		}
+	}
+}
+
+func greedyKCenter(dist [][]float64, k int) []int {
+	first, firstEccentricity := 0, math.Inf(1)
+	for v := range dist {
+		var eccentricity float64
+		for _, d := range dist[v] {
+			eccentricity = math.Max(eccentricity, d)
+		}
+		if eccentricity < firstEccentricity {
+			first, firstEccentricity = v, eccentricity
+		}
+	}
+	centers := []int{first}
+	nearest := append([]float64(nil), dist[first]...)
+	for len(centers) < k {
+		farthest := -1
+		for v, d := range nearest {
+			if d > 0 && (farthest == -1 || d > nearest[farthest]) {
+				farthest = v
+			}
+		}
+		if farthest == -1 {
+			break
+		}
+		centers = append(centers, farthest)
+		for v := range nearest {
+			nearest[v] = math.Min(nearest[v], dist[farthest][v])
+		}
+	}
+	sort.Ints(centers)
+	return centers
+}
+
+// combinationsUpTo returns the binomial coefficient of n and k, or a value
+// above limit as soon as it exceeds it.
+func combinationsUpTo(n, k, limit int) int {
+	if n-k < k {
+		k = n - k
+	}
+	res := 1
+	for i := 1; i <= k; i++ {
+		res = res * (n - k + i) / i
+		if res > limit {
+			return limit + 1
+		}
+	}
+	return res
+}
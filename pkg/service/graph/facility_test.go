@@ -0,0 +1,140 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func pathGraph(n uint64) model.Graph {
+	var pairs [][2]uint64
+	for i := uint64(1); i < n; i++ {
+		pairs = append(pairs, [2]uint64{i, i + 1})
+	}
+	return edgesGraph(false, pairs...)
+}
+
+func nodeIDs(nodes []model.Node) []uint64 {
+	var ids []uint64
+	for _, n := range nodes {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
+
+func TestGraph_DominatingSet(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph model.Graph
+		want  []uint64
+	}{
+		{
+			name:  "path",
+			graph: pathGraph(6),
+			want:  []uint64{2, 5},
+		},
+		{
+			name:  "out star",
+			graph: edgesGraph(true, [2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{1, 4}),
+			want:  []uint64{1},
+		},
+		{
+			name:  "in star",
+			graph: edgesGraph(true, [2]uint64{2, 1}, [2]uint64{3, 1}, [2]uint64{4, 1}),
+			want:  []uint64{2, 3, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Graph{}.DominatingSet(tt.graph)
+			assert.True(t, got.Exact)
+			assert.Equal(t, tt.want, nodeIDs(got.Nodes))
+		})
+	}
+}
+
+func TestGraph_DominatingSetGreedy(t *testing.T) {
+	graph := pathGraph(40)
+
+	got := Graph{}.DominatingSet(graph)
+	assert.False(t, got.Exact)
+	dominated := make(map[uint64]struct{})
+	for _, n := range got.Nodes {
+		dominated[n.ID] = struct{}{}
+		for _, e := range graph.Edges {
+			if e.From.ID == n.ID {
+				dominated[e.To.ID] = struct{}{}
+			}
+			if e.To.ID == n.ID {
+				dominated[e.From.ID] = struct{}{}
+			}
+		}
+	}
+	assert.Len(t, dominated, 40)
+	assert.LessOrEqual(t, len(got.Nodes), 20)
+}
+
+func TestGraph_KCenter(t *testing.T) {
+	tests := []struct {
+		name    string
+		graph   model.Graph
+		k       uint64
+		centers []uint64
+		radius  float64
+	}{
+		{
+			name:    "single center",
+			graph:   pathGraph(5),
+			k:       1,
+			centers: []uint64{3},
+			radius:  2,
+		},
+		{
+			name:    "two centers",
+			graph:   pathGraph(5),
+			k:       2,
+			centers: []uint64{1, 4},
+			radius:  1,
+		},
+		{
+			name:    "every node",
+			graph:   pathGraph(3),
+			k:       5,
+			centers: []uint64{1, 2, 3},
+			radius:  0,
+		},
+		{
+			name:    "one per component",
+			graph:   edgesGraph(false, [2]uint64{1, 2}, [2]uint64{3, 4}),
+			k:       2,
+			centers: []uint64{1, 3},
+			radius:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Graph{}.KCenter(tt.graph, tt.k)
+			require.NoError(t, err)
+			assert.True(t, got.Exact)
+			assert.Equal(t, tt.centers, nodeIDs(got.Centers))
+			assert.Equal(t, tt.radius, got.Radius)
+		})
+	}
+}
+
+func TestGraph_KCenterGreedy(t *testing.T) {
+	got, err := Graph{}.KCenter(pathGraph(60), 10)
+	require.NoError(t, err)
+	assert.False(t, got.Exact)
+	assert.Len(t, got.Centers, 10)
+	// The optimal radius is 3, farthest first stays within twice of it.
+	assert.LessOrEqual(t, got.Radius, 6.0)
+}
+
+func TestGraph_KCenterDisconnected(t *testing.T) {
+	_, err := Graph{}.KCenter(edgesGraph(false, [2]uint64{1, 2}, [2]uint64{3, 4}), 1)
+	assert.Equal(t, ErrNotConnected, err)
+}
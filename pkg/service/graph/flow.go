@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"math"
+)
+
+// flowEpsilon is the smallest residual capacity still treated as an arc.
+const flowEpsilon = 1e-12
+
+// flowNetwork is a residual network for Dinic's algorithm. Arcs are stored
+// in pairs, so the reverse of arc i is i^1; edge keeps the index of the
+// graph edge an arc was made from, or -1.
+type flowNetwork struct {
+	arcs     [][]int
+	to       []int
+	capacity []float64
+	initial  []float64
+	edge     []int
+	level    []int
+	next     []int
+}
+
+func newFlowNetwork(nodes int) *flowNetwork {
+	return &flowNetwork{
+		arcs:  make([][]int, nodes),
+		level: make([]int, nodes),
+		next:  make([]int, nodes),
+	}
+}
+
+func (f *flowNetwork) addArc(from, to int, capacity float64, edge int) {
+	f.arcs[from] = append(f.arcs[from], len(f.to))
+	f.to = append(f.to, to)
+	f.capacity = append(f.capacity, capacity)
+	f.edge = append(f.edge, edge)
+	f.arcs[to] = append(f.arcs[to], len(f.to))
+	f.to = append(f.to, from)
+	f.capacity = append(f.capacity, 0)
+	f.edge = append(f.edge, -1)
+	f.initial = append(f.initial, capacity, 0)
+}
+
+// reset drops any flow sent through the network.
+func (f *flowNetwork) reset() {
+	copy(f.capacity, f.initial)
+}
+
+// maxFlow sends as much flow from s to t as possible on top of the current
+// one and returns the amount sent.
+func (f *flowNetwork) maxFlow(s, t int) float64 {
+	var total float64
+	for f.levels(s, t) {
+		for i := range f.next {
+			f.next[i] = 0
+		}
+		for {
+			pushed := f.push(s, t, math.Inf(1))
+			if pushed <= flowEpsilon {
+				break
+			}
+			total += pushed
+		}
+	}
+	return total
+}
+
+func (f *flowNetwork) levels(s, t int) bool {
+	for i := range f.level {
+		f.level[i] = -1
+	}
+	f.level[s] = 0
+	queue := []int{s}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, a := range f.arcs[v] {
+			if f.capacity[a] > flowEpsilon && f.level[f.to[a]] == -1 {
+				f.level[f.to[a]] = f.level[v] + 1
+				queue = append(queue, f.to[a])
+			}
+		}
+	}
+	return f.level[t] != -1
+}
+
+func (f *flowNetwork) push(v, t int, limit float64) float64 {
+	if v == t {
+		return limit
+	}
+	for ; f.next[v] < len(f.arcs[v]); f.next[v]++ {
+		a := f.arcs[v][f.next[v]]
+		w := f.to[a]
+		if f.capacity[a] <= flowEpsilon || f.level[w] != f.level[v]+1 {
+			continue
+		}
+		if pushed := f.push(w, t, math.Min(limit, f.capacity[a])); pushed > flowEpsilon {
+			f.capacity[a] -= pushed
+			f.capacity[a^1] += pushed
+			return pushed
+		}
+	}
+	return 0
+}
+
+// reachable marks nodes reachable from s in the residual network, the
+// source side of a minimum cut after maxFlow.
+func (f *flowNetwork) reachable(s int) []bool {
+	seen := make([]bool, len(f.arcs))
+	seen[s] = true
+	stack := []int{s}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, a := range f.arcs[v] {
+			if f.capacity[a] > flowEpsilon && !seen[f.to[a]] {
+				seen[f.to[a]] = true
+				stack = append(stack, f.to[a])
+			}
+		}
+	}
+	return seen
+}
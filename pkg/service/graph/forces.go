@@ -0,0 +1,189 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+)
+
+const (
+	// forceIterations is the number of steps of the force simulation.
+	forceIterations = 500
+	// springTolerance stops moving nodes of the spring model once the
+	// energy gradient at every node is smaller.
+	springTolerance = 1e-3
+)
+
+// fruchtermanReingold lets adjacent nodes attract and all nodes repel each
+// other, limiting moves by a temperature that cools down linearly. Nodes
+// start on a circle, slightly shaken by a fixed seed so that symmetric
+// graphs don't get stuck.
+func fruchtermanReingold(adj adjacency) []point {
+	n := len(adj.nodes)
+	if n == 0 {
+		return nil
+	}
+	rng := rand.New(rand.NewSource(1))
+	pos := ring(n, ringRadius())
+	for i := range pos {
+		pos[i].x += rng.Float64() - 0.5
+		pos[i].y += rng.Float64() - 0.5
+	}
+	edges := simpleEdges(adj)
+	k := math.Sqrt(float64(canvasWidth*canvasHeight) / float64(n))
+	start := float64(canvasWidth) / 10
+
+	disp := make([]point, n)
+	for iter := 0; iter < forceIterations; iter++ {
+		for i := range disp {
+			disp[i] = point{}
+		}
+		for v := range pos {
+			for u := v + 1; u < n; u++ {
+				dx, dy, d := delta(pos[v], pos[u])
+				f := k * k / d
+				disp[v].x += dx / d * f
+				disp[v].y += dy / d * f
+				disp[u].x -= dx / d * f
+				disp[u].y -= dy / d * f
+			}
+		}
+		for _, e := range edges {
+			dx, dy, d := delta(pos[e[0]], pos[e[1]])
+			f := d * d / k
+			disp[e[0]].x -= dx / d * f
+			disp[e[0]].y -= dy / d * f
+			disp[e[1]].x += dx / d * f
+			disp[e[1]].y += dy / d * f
+		}
+		t := start * (1 - float64(iter)/forceIterations)
+		for v := range pos {
+			length := math.Hypot(disp[v].x, disp[v].y)
+			if length < 1e-12 {
+				continue
+			}
+			step := math.Min(length, t)
+			pos[v].x += disp[v].x / length * step
+			pos[v].y += disp[v].y / length * step
+		}
+	}
+	return pos
+}
+
+// delta returns the vector from b to a and its length, never 0, so that
+// coincident nodes still push each other apart.
+func delta(a, b point) (dx, dy, d float64) {
+	dx, dy = a.x-b.x, a.y-b.y
+	d = math.Hypot(dx, dy)
+	if d < 1e-6 {
+		return 1e-6, 0, 1e-6
+	}
+	return dx, dy, d
+}
+
+// kamadaKawai connects every pair of nodes with a spring whose length is
+// proportional to the number of edges between them, and moves one node at
+// a time to the minimum of the energy with Newton's method, always the one
+// with the largest gradient. Nodes of different components are kept a
+// step further apart than the farthest connected pair.
+func kamadaKawai(adj adjacency) []point {
+	n := len(adj.nodes)
+	pos := ring(n, ringRadius())
+	if n < 2 {
+		return pos
+	}
+	dist := adj.unweighted().allDistances()
+	var longest float64
+	for v := range dist {
+		for _, d := range dist[v] {
+			if !math.IsInf(d, 1) {
+				longest = math.Max(longest, d)
+			}
+		}
+	}
+	longest++
+	unit := math.Min(canvasWidth, canvasHeight) / longest
+	length := make([][]float64, n)
+	strength := make([][]float64, n)
+	for v := range dist {
+		length[v] = make([]float64, n)
+		strength[v] = make([]float64, n)
+		for u, d := range dist[v] {
+			if u == v {
+				continue
+			}
+			if math.IsInf(d, 1) {
+				d = longest
+			}
+			length[v][u] = unit * d
+			strength[v][u] = 1 / (d * d)
+		}
+	}
+
+	// pull is the energy gradient at node m at p caused by the spring to
+	// node i at q.
+	pull := func(m, i int, p, q point) (gx, gy float64) {
+		dx, dy, d := delta(p, q)
+		return strength[m][i] * (dx - length[m][i]*dx/d), strength[m][i] * (dy - length[m][i]*dy/d)
+	}
+	gradient := func(m int) (gx, gy float64) {
+		for i := range pos {
+			if i != m {
+				x, y := pull(m, i, pos[m], pos[i])
+				gx, gy = gx+x, gy+y
+			}
+		}
+		return gx, gy
+	}
+	gx := make([]float64, n)
+	gy := make([]float64, n)
+	for v := range pos {
+		gx[v], gy[v] = gradient(v)
+	}
+	for iter := 0; iter < maxIterations; iter++ {
+		m, largest := -1, springTolerance
+		for v := range pos {
+			if g := math.Hypot(gx[v], gy[v]); g > largest {
+				m, largest = v, g
+			}
+		}
+		if m == -1 {
+			break
+		}
+		start := pos[m]
+		for step := 0; step < 100; step++ {
+			gx[m], gy[m] = gradient(m)
+			if math.Hypot(gx[m], gy[m]) <= springTolerance {
+				break
+			}
+			var hxx, hxy, hyy float64
+			for i := range pos {
+				if i == m {
+					continue
+				}
+				dx, dy, d := delta(pos[m], pos[i])
+				d3 := d * d * d
+				hxx += strength[m][i] * (1 - length[m][i]*dy*dy/d3)
+				hxy += strength[m][i] * length[m][i] * dx * dy / d3
+				hyy += strength[m][i] * (1 - length[m][i]*dx*dx/d3)
+			}
+			det := hxx*hyy - hxy*hxy
+			if math.Abs(det) < 1e-12 {
+				break
+			}
+			pos[m].x += (hxy*gy[m] - hyy*gx[m]) / det
+			pos[m].y += (hxy*gx[m] - hxx*gy[m]) / det
+		}
+		// Only springs to the moved node changed for the others.
+		gx[m], gy[m] = gradient(m)
+		for i := range pos {
+			if i == m {
+				continue
+			}
+			oldX, oldY := pull(i, m, pos[i], start)
+			newX, newY := pull(i, m, pos[i], pos[m])
+			gx[i] += newX - oldX
+			gy[i] += newY - oldY
+		}
+	}
+	return pos
+}
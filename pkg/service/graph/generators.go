@@ -0,0 +1,286 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+type GeneratorKind string
+
+const (
+	CompleteGenerator          GeneratorKind = "complete"
+	CompleteBipartiteGenerator GeneratorKind = "completeBipartite"
+	CycleGenerator             GeneratorKind = "cycle"
+	PathGenerator              GeneratorKind = "path"
+	StarGenerator              GeneratorKind = "star"
+	WheelGenerator             GeneratorKind = "wheel"
+	GridGenerator              GeneratorKind = "grid"
+	HypercubeGenerator         GeneratorKind = "hypercube"
+	PetersenGenerator          GeneratorKind = "petersen"
+	ErdosRenyiGenerator        GeneratorKind = "erdosRenyi"
+	WattsStrogatzGenerator     GeneratorKind = "wattsStrogatz"
+	BarabasiAlbertGenerator    GeneratorKind = "barabasiAlbert"
+)
+
+// maxGeneratedNodes limits the size of generated graphs.
+const maxGeneratedNodes = 1000
+
+var ErrInvalidParameters = errors.New("invalid generator parameters")
+
+// GeneratorParams are the sizes and probabilities of a generated graph;
+// every kind documents which of them it reads.
+type GeneratorParams struct {
+	N    uint64
+	M    uint64
+	K    uint64
+	P    float64
+	Seed int64
+}
+
+// Generate builds an undirected graph of a standard family with nodes
+// numbered from 1 and laid out on the canvas:
+//   - complete: N nodes;
+//   - completeBipartite: N nodes on the left and M on the right;
+//   - cycle and path: N nodes;
+//   - star: node 1 joined with N leaves;
+//   - wheel: node 1 joined with a cycle of N nodes;
+//   - grid: N rows of M nodes;
+//   - hypercube: 2^N nodes joined when their numbers differ in one bit;
+//   - petersen: the Petersen graph;
+//   - erdosRenyi: N nodes, each pair joined with probability P;
+//   - wattsStrogatz: a ring of N nodes joined with K nearest ones, where
+//     every edge is rewired with probability P;
+//   - barabasiAlbert: N nodes, each joined with K earlier ones chosen by
+//     preferential attachment.
+//
+// Random graphs are reproducible for the same seed.
+func (g Graph) Generate(name string, kind GeneratorKind, params GeneratorParams) (model.Graph, error) {
+	n, m, k := int(params.N), int(params.M), int(params.K)
+	if params.N > maxGeneratedNodes || params.M > maxGeneratedNodes || params.K > maxGeneratedNodes ||
+		params.P < 0 || params.P > 1 {
+		return model.Graph{}, ErrInvalidParameters
+	}
+	b := newBuilder(name)
+	var positions func(nodes []model.Node) map[uint64]point
+	circular := func(nodes []model.Node) map[uint64]point {
+		return positionsOf(nodes, ring(len(nodes), ringRadius()))
+	}
+	rng := rand.New(rand.NewSource(params.Seed))
+
+	switch kind {
+	case CompleteGenerator:
+		if n < 1 {
+			return model.Graph{}, ErrInvalidParameters
+		}
+		nodes := b.addNumberedNodes(n)
+		for i := range nodes {
+			for j := i + 1; j < n; j++ {
+				b.connect(nodes[i], nodes[j], false)
+			}
+		}
+		positions = circular
+	case CompleteBipartiteGenerator:
+		if n < 1 || m < 1 || n+m > maxGeneratedNodes {
+			return model.Graph{}, ErrInvalidParameters
+		}
+		nodes := b.addNumberedNodes(n + m)
+		for i := 0; i < n; i++ {
+			for j := n; j < n+m; j++ {
+				b.connect(nodes[i], nodes[j], false)
+			}
+		}
+		positions = func(nodes []model.Node) map[uint64]point {
+			points := make([]point, 0, n+m)
+			for _, p := range gridPoints(n, 1) {
+				p.x = canvasWidth / 3
+				points = append(points, p)
+			}
+			for _, p := range gridPoints(m, 1) {
+				p.x = 2 * canvasWidth / 3
+				points = append(points, p)
+			}
+			return positionsOf(nodes, points)
+		}
+	case CycleGenerator, PathGenerator:
+		if n < 1 || kind == CycleGenerator && n < 3 {
+			return model.Graph{}, ErrInvalidParameters
+		}
+		nodes := b.addNumberedNodes(n)
+		for i := 1; i < n; i++ {
+			b.connect(nodes[i-1], nodes[i], false)
+		}
+		if kind == CycleGenerator {
+			b.connect(nodes[n-1], nodes[0], false)
+			positions = circular
+		} else {
+			positions = func(nodes []model.Node) map[uint64]point {
+				return positionsOf(nodes, gridPoints(1, n))
+			}
+		}
+	case StarGenerator, WheelGenerator:
+		if n < 1 || kind == WheelGenerator && n < 3 || n+1 > maxGeneratedNodes {
+			return model.Graph{}, ErrInvalidParameters
+		}
+		nodes := b.addNumberedNodes(n + 1)
+		for i := 1; i <= n; i++ {
+			b.connect(nodes[0], nodes[i], false)
+		}
+		if kind == WheelGenerator {
+			for i := 1; i <= n; i++ {
+				b.connect(nodes[i], nodes[i%n+1], false)
+			}
+		}
+		positions = func(nodes []model.Node) map[uint64]point {
+			points := append([]point{{x: canvasWidth / 2, y: canvasHeight / 2}}, ring(n, ringRadius())...)
+			return positionsOf(nodes, points)
+		}
+	case GridGenerator:
+		if n < 1 || m < 1 || n*m > maxGeneratedNodes {
+			return model.Graph{}, ErrInvalidParameters
+		}
+		nodes := b.addNumberedNodes(n * m)
+		for i := 0; i < n; i++ {
+			for j := 0; j < m; j++ {
+				if j+1 < m {
+					b.connect(nodes[i*m+j], nodes[i*m+j+1], false)
+				}
+				if i+1 < n {
+					b.connect(nodes[i*m+j], nodes[(i+1)*m+j], false)
+				}
+			}
+		}
+		positions = func(nodes []model.Node) map[uint64]point {
+			return positionsOf(nodes, gridPoints(n, m))
+		}
+	case HypercubeGenerator:
+		if n >= 32 || 1<<uint(n) > maxGeneratedNodes {
+			return model.Graph{}, ErrInvalidParameters
+		}
+		size := 1 << uint(n)
+		nodes := b.addNumberedNodes(size)
+		for v := 0; v < size; v++ {
+			for bit := 0; bit < n; bit++ {
+				if u := v ^ 1<<uint(bit); u > v {
+					b.connect(nodes[v], nodes[u], false)
+				}
+			}
+		}
+		positions = circular
+	case PetersenGenerator:
+		nodes := b.addNumberedNodes(10)
+		for i := 0; i < 5; i++ {
+			b.connect(nodes[i], nodes[(i+1)%5], false)
+			b.connect(nodes[i], nodes[i+5], false)
+			b.connect(nodes[i+5], nodes[(i+2)%5+5], false)
+		}
+		positions = func(nodes []model.Node) map[uint64]point {
+			return positionsOf(nodes, append(ring(5, ringRadius()), ring(5, ringRadius()/2)...))
+		}
+	case ErdosRenyiGenerator:
+		if n < 1 {
+			return model.Graph{}, ErrInvalidParameters
+		}
+		nodes := b.addNumberedNodes(n)
+		for i := range nodes {
+			for j := i + 1; j < n; j++ {
+				if rng.Float64() < params.P {
+					b.connect(nodes[i], nodes[j], false)
+				}
+			}
+		}
+		positions = circular
+	case WattsStrogatzGenerator:
+		if k < 2 || k%2 != 0 || k >= n {
+			return model.Graph{}, ErrInvalidParameters
+		}
+		nodes := b.addNumberedNodes(n)
+		for _, e := range wattsStrogatz(n, k, params.P, rng) {
+			b.connect(nodes[e[0]], nodes[e[1]], false)
+		}
+		positions = circular
+	case BarabasiAlbertGenerator:
+		if k < 1 || k >= n {
+			return model.Graph{}, ErrInvalidParameters
+		}
+		nodes := b.addNumberedNodes(n)
+		for _, e := range barabasiAlbert(n, k, rng) {
+			b.connect(nodes[e[0]], nodes[e[1]], false)
+		}
+		positions = circular
+	default:
+		return model.Graph{}, ErrUnknownKind
+	}
+
+	graph := b.result()
+	return setPositions(graph, positions(graph.Nodes)), nil
+}
+
+// wattsStrogatz joins every node of a ring with k/2 following ones and
+// moves the far end of each such edge to a random node with probability p,
+// unless that would make a loop or a parallel edge.
+func wattsStrogatz(n, k int, p float64, rng *rand.Rand) [][2]int {
+	adjacent := make([]map[int]struct{}, n)
+	for v := range adjacent {
+		adjacent[v] = make(map[int]struct{})
+	}
+	var edges [][2]int
+	for v := 0; v < n; v++ {
+		for j := 1; j <= k/2; j++ {
+			u := (v + j) % n
+			adjacent[v][u] = struct{}{}
+			adjacent[u][v] = struct{}{}
+			edges = append(edges, [2]int{v, u})
+		}
+	}
+	for i, e := range edges {
+		if rng.Float64() >= p || len(adjacent[e[0]]) >= n-1 {
+			continue
+		}
+		u := rng.Intn(n)
+		for {
+			if _, ok := adjacent[e[0]][u]; !ok && u != e[0] {
+				break
+			}
+			u = rng.Intn(n)
+		}
+		delete(adjacent[e[0]], e[1])
+		delete(adjacent[e[1]], e[0])
+		adjacent[e[0]][u] = struct{}{}
+		adjacent[u][e[0]] = struct{}{}
+		edges[i][1] = u
+	}
+	return edges
+}
+
+// barabasiAlbert joins each node from the k-th on with k distinct earlier
+// nodes, chosen with probability proportional to their degree. The first
+// new node is joined with all k initial ones.
+func barabasiAlbert(n, k int, rng *rand.Rand) [][2]int {
+	targets := make([]int, k)
+	for i := range targets {
+		targets[i] = i
+	}
+	var edges [][2]int
+	var repeated []int
+	for v := k; v < n; v++ {
+		for _, t := range targets {
+			edges = append(edges, [2]int{t, v})
+		}
+		repeated = append(repeated, targets...)
+		for range targets {
+			repeated = append(repeated, v)
+		}
+		chosen := make(map[int]struct{}, k)
+		targets = targets[:0]
+		for len(targets) < k {
+			t := repeated[rng.Intn(len(repeated))]
+			if _, ok := chosen[t]; !ok {
+				chosen[t] = struct{}{}
+				targets = append(targets, t)
+			}
+		}
+	}
+	return edges
+}
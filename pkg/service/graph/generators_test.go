@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraph_Generate(t *testing.T) {
+	tests := []struct {
+		kind   GeneratorKind
+		params GeneratorParams
+		nodes  uint64
+		edges  uint64
+		degree [2]uint64
+	}{
+		{kind: CompleteGenerator, params: GeneratorParams{N: 5}, nodes: 5, edges: 10, degree: [2]uint64{4, 4}},
+		{kind: CompleteBipartiteGenerator, params: GeneratorParams{N: 2, M: 3}, nodes: 5, edges: 6, degree: [2]uint64{2, 3}},
+		{kind: CycleGenerator, params: GeneratorParams{N: 6}, nodes: 6, edges: 6, degree: [2]uint64{2, 2}},
+		{kind: PathGenerator, params: GeneratorParams{N: 4}, nodes: 4, edges: 3, degree: [2]uint64{1, 2}},
+		{kind: StarGenerator, params: GeneratorParams{N: 4}, nodes: 5, edges: 4, degree: [2]uint64{1, 4}},
+		{kind: WheelGenerator, params: GeneratorParams{N: 5}, nodes: 6, edges: 10, degree: [2]uint64{3, 5}},
+		{kind: GridGenerator, params: GeneratorParams{N: 3, M: 4}, nodes: 12, edges: 17, degree: [2]uint64{2, 4}},
+		{kind: HypercubeGenerator, params: GeneratorParams{N: 3}, nodes: 8, edges: 12, degree: [2]uint64{3, 3}},
+		{kind: PetersenGenerator, nodes: 10, edges: 15, degree: [2]uint64{3, 3}},
+		{kind: WattsStrogatzGenerator, params: GeneratorParams{N: 20, K: 4, P: 0.3, Seed: 7}, nodes: 20, edges: 40},
+		{kind: BarabasiAlbertGenerator, params: GeneratorParams{N: 20, K: 2, Seed: 7}, nodes: 20, edges: 36},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			graph, err := Graph{}.Generate("generated", tt.kind, tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, "generated", graph.Name)
+			stats := Graph{}.Stats(graph)
+			assert.Equal(t, tt.nodes, stats.Nodes)
+			assert.Equal(t, tt.edges, stats.Edges)
+			assert.Zero(t, stats.SelfLoops)
+			assert.Zero(t, stats.ParallelEdges)
+			if tt.degree != [2]uint64{} {
+				assert.Equal(t, tt.degree, [2]uint64{stats.Degree.Min, stats.Degree.Max})
+			}
+			positions := make(map[[2]uint64]struct{})
+			for _, n := range graph.Nodes {
+				assert.True(t, n.X <= canvasWidth && n.Y <= canvasHeight, "node %d is off the canvas", n.ID)
+				positions[[2]uint64{n.X, n.Y}] = struct{}{}
+			}
+			assert.Len(t, positions, len(graph.Nodes), "nodes share a position")
+		})
+	}
+}
+
+func TestGraph_GenerateRandom(t *testing.T) {
+	for _, kind := range []GeneratorKind{ErdosRenyiGenerator, WattsStrogatzGenerator, BarabasiAlbertGenerator} {
+		t.Run(string(kind), func(t *testing.T) {
+			params := GeneratorParams{N: 30, K: 4, P: 0.2, Seed: 42}
+			first, err := Graph{}.Generate("", kind, params)
+			require.NoError(t, err)
+			second, err := Graph{}.Generate("", kind, params)
+			require.NoError(t, err)
+			assert.Equal(t, first, second)
+
+			params.Seed = 43
+			third, err := Graph{}.Generate("", kind, params)
+			require.NoError(t, err)
+			assert.NotEqual(t, edgePairs(first), edgePairs(third))
+		})
+	}
+
+	empty, err := Graph{}.Generate("", ErdosRenyiGenerator, GeneratorParams{N: 10, P: 0})
+	require.NoError(t, err)
+	assert.Empty(t, empty.Edges)
+	full, err := Graph{}.Generate("", ErdosRenyiGenerator, GeneratorParams{N: 10, P: 1})
+	require.NoError(t, err)
+	assert.Len(t, full.Edges, 45)
+}
+
+func TestGraph_GenerateErrors(t *testing.T) {
+	tests := []struct {
+		kind   GeneratorKind
+		params GeneratorParams
+		want   error
+	}{
+		{kind: CycleGenerator, params: GeneratorParams{N: 2}, want: ErrInvalidParameters},
+		{kind: GridGenerator, params: GeneratorParams{N: 100, M: 100}, want: ErrInvalidParameters},
+		{kind: ErdosRenyiGenerator, params: GeneratorParams{N: 5, P: 1.5}, want: ErrInvalidParameters},
+		{kind: WattsStrogatzGenerator, params: GeneratorParams{N: 10, K: 3}, want: ErrInvalidParameters},
+		{kind: BarabasiAlbertGenerator, params: GeneratorParams{N: 3, K: 3}, want: ErrInvalidParameters},
+		{kind: "tree", params: GeneratorParams{N: 3}, want: ErrUnknownKind},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			_, err := Graph{}.Generate("", tt.kind, tt.params)
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package graph
+
+import (
+	"math"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+type EdgeCrossing struct {
+	First  uint64  `json:"first"`
+	Second uint64  `json:"second"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+}
+
+type Geometry struct {
+	Lengths       map[uint64]float64 `json:"lengths"`
+	TotalLength   float64            `json:"totalLength"`
+	CrossingCount int                `json:"crossingCount"`
+	Crossings     []EdgeCrossing     `json:"crossings"`
+}
+
+// Orient returns a copy of the graph with angles of every edge computed
+// from the positions of its endpoints: Angle12 points from the first node
+// to the second and Angle21 back. Positions in the node list take
+// precedence over the copies inside edges. Edges of zero length get zero
+// angles.
+func (g Graph) Orient(graph model.Graph) model.Graph {
+	positions := nodePositions(graph)
+	edges := make([]model.Edge, 0, len(graph.Edges))
+	for _, e := range graph.Edges {
+		from, to := positions[e.From.ID], positions[e.To.ID]
+		e.Angle12, e.Angle21 = model.Angle{}, model.Angle{}
+		if length := math.Hypot(to.x-from.x, to.y-from.y); length > 0 {
+			e.Angle12 = model.Angle{Sin: (to.y - from.y) / length, Cos: (to.x - from.x) / length}
+			e.Angle21 = model.Angle{Sin: -e.Angle12.Sin, Cos: -e.Angle12.Cos}
+		}
+		edges = append(edges, e)
+	}
+	graph.Edges = edges
+	return graph
+}
+
+// Geometry measures the current drawing of the graph: the length of every
+// edge and the points where edges cross. Edges sharing a node never cross
+// and overlapping edges cross at a point they share. Parallel edges cross
+// other edges once, under the ID of the first of them. Only the first
+// maxListedCrossings crossings are listed and graphs with more than
+// maxCrossingEdges edges are rejected with ErrTooManyEdges.
+func (g Graph) Geometry(graph model.Graph) (Geometry, error) {
+	count, crossings, err := edgeCrossings(graph)
+	if err != nil {
+		return Geometry{}, err
+	}
+	positions := nodePositions(graph)
+	res := Geometry{
+		Lengths:       make(map[uint64]float64, len(graph.Edges)),
+		CrossingCount: count,
+		Crossings:     crossings,
+	}
+	for _, e := range graph.Edges {
+		from, to := positions[e.From.ID], positions[e.To.ID]
+		length := math.Hypot(to.x-from.x, to.y-from.y)
+		res.Lengths[e.ID] = length
+		res.TotalLength += length
+	}
+	return res, nil
+}
+
+// nodePositions returns the position of every node, preferring the node
+// list over the copies inside edges.
+func nodePositions(graph model.Graph) map[uint64]point {
+	nodes := graphNodes(graph)
+	positions := make(map[uint64]point, len(nodes))
+	for _, n := range nodes {
+		positions[n.ID] = point{x: float64(n.X), y: float64(n.Y)}
+	}
+	return positions
+}
+
+// edgeCrossings counts crossings of the segments drawn between adjacent
+// nodes and lists the first maxListedCrossings of them, in order of
+// simpleEdges. Parallel edges are drawn as one segment named after the
+// first of them and loops aren't drawn. Graphs with more than
+// maxCrossingEdges edges are rejected with ErrTooManyEdges.
+func edgeCrossings(graph model.Graph) (int, []EdgeCrossing, error) {
+	if len(graph.Edges) > maxCrossingEdges {
+		return 0, nil, ErrTooManyEdges
+	}
+	adj := newUndirectedAdjacency(graph)
+	points := make([]point, len(adj.nodes))
+	for i, n := range adj.nodes {
+		points[i] = point{x: float64(n.X), y: float64(n.Y)}
+	}
+	first := make(map[[2]int]uint64, len(graph.Edges))
+	for i := len(graph.Edges) - 1; i >= 0; i-- {
+		e := graph.Edges[i]
+		from, to := adj.index[e.From.ID], adj.index[e.To.ID]
+		if from > to {
+			from, to = to, from
+		}
+		first[[2]int{from, to}] = e.ID
+	}
+
+	edges := simpleEdges(adj)
+	count := 0
+	crossings := make([]EdgeCrossing, 0)
+	eachCrossing(edges, points, -1, func(i, j int) {
+		count++
+		if len(crossings) < maxListedCrossings {
+			e, f := edges[i], edges[j]
+			p := intersection(points[e[0]], points[e[1]], points[f[0]], points[f[1]])
+			crossings = append(crossings, EdgeCrossing{First: first[e], Second: first[f], X: p.x, Y: p.y})
+		}
+	})
+	return count, crossings, nil
+}
+
+// intersection returns the point where crossing segments ab and cd meet.
+// For overlapping segments it is an endpoint lying on the other segment.
+func intersection(a, b, c, d point) point {
+	denominator := (b.x-a.x)*(d.y-c.y) - (b.y-a.y)*(d.x-c.x)
+	if math.Abs(denominator) > 1e-9 {
+		t := ((c.x-a.x)*(d.y-c.y) - (c.y-a.y)*(d.x-c.x)) / denominator
+		return point{x: a.x + t*(b.x-a.x), y: a.y + t*(b.y-a.y)}
+	}
+	switch {
+	case orientation(a, b, c) == 0 && onSegment(a, b, c):
+		return c
+	case orientation(a, b, d) == 0 && onSegment(a, b, d):
+		return d
+	case orientation(c, d, a) == 0 && onSegment(c, d, a):
+		return a
+	}
+	return b
+}
@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/illfate2/graph-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// placedGraph connects nodes placed at the given positions, listing the
+// nodes with their positions and leaving stale positions in edge copies.
+func placedGraph(positions map[uint64][2]uint64, pairs ...[2]uint64) model.Graph {
+	graph := edgesGraph(false, pairs...)
+	for _, n := range graphNodes(graph) {
+		p := positions[n.ID]
+		graph.Nodes = append(graph.Nodes, model.Node{ID: n.ID, X: p[0], Y: p[1]})
+	}
+	return graph
+}
+
+func TestGraph_Orient(t *testing.T) {
+	graph := placedGraph(map[uint64][2]uint64{1: {0, 0}, 2: {30, 40}, 3: {30, 40}}, [2]uint64{1, 2}, [2]uint64{2, 3})
+	graph.Edges[1].Angle12 = model.Angle{Sin: 1}
+
+	got := Graph{}.Orient(graph)
+	require.Len(t, got.Edges, 2)
+	assert.InDelta(t, 0.6, got.Edges[0].Angle12.Cos, 1e-9)
+	assert.InDelta(t, 0.8, got.Edges[0].Angle12.Sin, 1e-9)
+	assert.InDelta(t, -0.6, got.Edges[0].Angle21.Cos, 1e-9)
+	assert.InDelta(t, -0.8, got.Edges[0].Angle21.Sin, 1e-9)
+	assert.Equal(t, model.Angle{}, got.Edges[1].Angle12)
+	assert.Equal(t, model.Angle{}, got.Edges[1].Angle21)
+	assert.Equal(t, model.Angle{Sin: 1}, graph.Edges[1].Angle12, "the input is kept")
+}
+
+func TestGraph_Geometry(t *testing.T) {
+	square := placedGraph(map[uint64][2]uint64{1: {0, 0}, 2: {100, 0}, 3: {100, 100}, 4: {0, 100}},
+		[2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 4}, [2]uint64{4, 1}, [2]uint64{1, 3}, [2]uint64{2, 4})
+	got, err := Graph{}.Geometry(square)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.CrossingCount)
+	assert.Equal(t, []EdgeCrossing{{First: 5, Second: 6, X: 50, Y: 50}}, got.Crossings)
+	assert.InDelta(t, 100, got.Lengths[1], 1e-9)
+	assert.InDelta(t, 141.4213562, got.Lengths[5], 1e-6)
+	assert.InDelta(t, 400+2*141.4213562, got.TotalLength, 1e-6)
+
+	overlapping := placedGraph(map[uint64][2]uint64{1: {0, 0}, 2: {100, 0}, 3: {50, 0}, 4: {200, 0}, 5: {300, 0}},
+		[2]uint64{1, 2}, [2]uint64{3, 4}, [2]uint64{4, 5})
+	got, err = Graph{}.Geometry(overlapping)
+	require.NoError(t, err)
+	assert.Equal(t, []EdgeCrossing{{First: 1, Second: 2, X: 50, Y: 0}}, got.Crossings)
+
+	got, err = Graph{}.Geometry(model.Graph{})
+	require.NoError(t, err)
+	assert.Empty(t, got.Crossings)
+	assert.Empty(t, got.Lengths)
+}
@@ -0,0 +1,59 @@
+package graph
+
+import (
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+// GomoryHuTree builds the cut tree of the graph treating every edge as
+// undirected, with weights as capacities. The tree keeps node IDs and
+// attributes and the weight of each of its edges is the minimum cut
+// between its endpoints; the minimum cut between any two nodes is the
+// lightest edge on the tree path between them. Nodes of different
+// components are joined by edges of weight 0. Negative weights are
+// rejected.
+func (g Graph) GomoryHuTree(graph model.Graph) (model.Graph, error) {
+	if err := checkWeights(graph); err != nil {
+		return model.Graph{}, err
+	}
+	adj := newUndirectedAdjacency(graph)
+	n := len(adj.nodes)
+	network := newFlowNetwork(n)
+	for v := range adj.nodes {
+		for _, a := range adj.out[v] {
+			if a.to != v {
+				network.addArc(v, a.to, a.weight, a.edge)
+			}
+		}
+	}
+
+	// Gusfield's algorithm with the reassignment step, so that the result
+	// is a cut tree and not only a flow equivalent one.
+	parent := make([]int, n)
+	cut := make([]float64, n)
+	for s := 1; s < n; s++ {
+		t := parent[s]
+		network.reset()
+		cut[s] = network.maxFlow(s, t)
+		side := network.reachable(s)
+		for i := s + 1; i < n; i++ {
+			if side[i] && parent[i] == t {
+				parent[i] = s
+			}
+		}
+		if side[parent[t]] {
+			parent[s], parent[t] = parent[t], s
+			cut[s], cut[t] = cut[t], cut[s]
+		}
+	}
+
+	tree := model.Graph{Name: graph.Name, Nodes: adj.nodes}
+	for v := 1; v < n; v++ {
+		tree.Edges = append(tree.Edges, model.Edge{
+			ID:     uint64(len(tree.Edges) + 1),
+			From:   adj.nodes[parent[v]],
+			To:     adj.nodes[v],
+			Weight: cut[v],
+		})
+	}
+	return tree, nil
+}
@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_GomoryHuTree(t *testing.T) {
+	weighted := edgesGraph(false,
+		[2]uint64{1, 2}, [2]uint64{1, 6}, [2]uint64{2, 3}, [2]uint64{2, 5}, [2]uint64{2, 6},
+		[2]uint64{3, 4}, [2]uint64{3, 5}, [2]uint64{4, 5}, [2]uint64{4, 6}, [2]uint64{5, 6})
+	for i, w := range []float64{10, 8, 4, 2, 3, 5, 4, 7, 2, 3} {
+		weighted.Edges[i].Weight = w
+	}
+	directed := edgesGraph(true, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1}, [2]uint64{3, 4})
+	disconnected := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{4, 5})
+
+	for name, graph := range map[string]model.Graph{
+		"weighted":     weighted,
+		"directed":     directed,
+		"disconnected": disconnected,
+	} {
+		t.Run(name, func(t *testing.T) {
+			tree, err := Graph{}.GomoryHuTree(graph)
+			require.NoError(t, err)
+			nodes := graphNodes(graph)
+			require.Equal(t, nodes, tree.Nodes)
+			require.Len(t, tree.Edges, len(nodes)-1)
+			_, components := newAdjacency(tree).components()
+			require.Equal(t, 1, components)
+
+			for _, s := range nodes {
+				for _, u := range nodes {
+					if s.ID < u.ID {
+						assert.InDelta(t, minCut(graph, s.ID, u.ID), treeMinCut(tree, s.ID, u.ID), 1e-9,
+							"cut between %d and %d", s.ID, u.ID)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGraph_GomoryHuTreeNegativeWeight(t *testing.T) {
+	graph := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3})
+	graph.Edges[1].Weight = -2
+
+	_, err := Graph{}.GomoryHuTree(graph)
+	assert.Equal(t, ErrNegativeWeight, err)
+}
+
+func minCut(graph model.Graph, s, t uint64) float64 {
+	adj := newUndirectedAdjacency(graph)
+	network := newFlowNetwork(len(adj.nodes))
+	for v := range adj.nodes {
+		for _, a := range adj.out[v] {
+			network.addArc(v, a.to, a.weight, a.edge)
+		}
+	}
+	return network.maxFlow(adj.index[s], adj.index[t])
+}
+
+// treeMinCut returns the lightest edge weight on the tree path from s to t.
+func treeMinCut(tree model.Graph, s, t uint64) float64 {
+	adj := newUndirectedAdjacency(tree)
+	best := make([]float64, len(adj.nodes))
+	for i := range best {
+		best[i] = -1
+	}
+	best[adj.index[s]] = math.Inf(1)
+	stack := []int{adj.index[s]}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, a := range adj.out[v] {
+			if best[a.to] == -1 {
+				best[a.to] = math.Min(best[v], tree.Edges[a.edge].Weight)
+				stack = append(stack, a.to)
+			}
+		}
+	}
+	return best[adj.index[t]]
+}
@@ -35,6 +35,46 @@ type Methods interface {
 	EulerianCycle(graph model.Graph, orig uint64) ([]model.Node, bool)
 	Cartesian(first, second model.Graph) model.Graph
 	IsTree(graph model.Graph) bool
+	FindPattern(graph, pattern model.Graph, induced bool, limit uint64, found func(Match) bool)
+	Centrality(graph model.Graph, kind CentralityKind, normalized bool) (map[uint64]float64, error)
+	Communities(graph model.Graph, kind CommunityKind) (Communities, error)
+	ColorCommunities(graph model.Graph, membership map[uint64]int) model.Graph
+	Stats(graph model.Graph) Stats
+	Spectrum(graph model.Graph) (Spectrum, error)
+	SpanningTreeCount(graph model.Graph) (SpanningTreeCount, error)
+	ArborescenceCount(graph model.Graph, root uint64) (SpanningTreeCount, error)
+	Transform(graph model.Graph, kind TransformKind, k uint64) (model.Graph, error)
+	Product(first, second model.Graph, kind ProductKind) (model.Graph, error)
+	Combine(first, second model.Graph, op SetOperation, matchBy MatchBy) (model.Graph, error)
+	InducedSubgraph(graph model.Graph, nodeIDs []uint64) model.Graph
+	Ego(graph model.Graph, node, radius uint64) (model.Graph, error)
+	ContractEdge(graph model.Graph, edgeID uint64, keepParallel, keepLoops bool) (model.Graph, error)
+	MergeNodes(graph model.Graph, nodeIDs []uint64, keepParallel, keepLoops bool) (model.Graph, error)
+	ReachabilityMatrix(graph model.Graph) AdjacencyMatrix
+	TransitiveClosure(graph model.Graph) model.Graph
+	TransitiveReduction(graph model.Graph) (model.Graph, error)
+	Dominators(graph model.Graph, root uint64) (Dominators, error)
+	Cores(graph model.Graph, k uint64) Cores
+	Cycles(graph model.Graph, enumerate bool, limit uint64) (Cycles, error)
+	Connectivity(graph model.Graph) Connectivity
+	GomoryHuTree(graph model.Graph) (model.Graph, error)
+	SteinerTree(graph model.Graph, terminals []uint64) (SteinerTree, error)
+	DominatingSet(graph model.Graph) DominatingSet
+	KCenter(graph model.Graph, k uint64) (KCenter, error)
+	RootTree(graph model.Graph, root uint64) (RootedTree, error)
+	LowestCommonAncestor(graph model.Graph, root uint64, nodeIDs []uint64) (uint64, error)
+	PruferCode(graph model.Graph) ([]uint64, error)
+	FromPrufer(name string, code []uint64) (model.Graph, error)
+	TreeIsomorphic(first, second model.Graph) (bool, error)
+	Centroid(graph model.Graph) ([]uint64, error)
+	RealizeDegrees(name string, degrees []uint64) (model.Graph, GraphicalCheck, error)
+	RealizeDirectedDegrees(name string, out, in []uint64) (model.Graph, GraphicalCheck, error)
+	Generate(name string, kind GeneratorKind, params GeneratorParams) (model.Graph, error)
+	Layout(graph model.Graph, kind LayoutKind) (model.Graph, error)
+	Orient(graph model.Graph) model.Graph
+	Geometry(graph model.Graph) (Geometry, error)
+	Crossings(graph model.Graph) (Crossings, error)
+	Untangle(graph model.Graph, seed int64) (model.Graph, error)
 }
 
 type Graph struct {
@@ -584,10 +624,18 @@ func nodeToAllNodesAsNode(graph model.Graph) map[model.Node][]model.Node {
 	return nodeToSorted
 }
 
+// toUndirectedGraph returns the underlying simple graph: directions are
+// dropped, parallel edges collapse into one and self loops are left out.
 func toUndirectedGraph(g model.Graph) *simple.UndirectedGraph {
 	undirGraph := simple.NewUndirectedGraph()
 
+	for _, n := range graphNodes(g) {
+		undirGraph.AddNode(simple.Node(n.ID))
+	}
 	for _, e := range g.Edges {
+		if e.From.ID == e.To.ID {
+			continue
+		}
 		undirGraph.SetEdge(simple.Edge{
 			F: simple.Node(e.From.ID),
 			T: simple.Node(e.To.ID),
@@ -683,6 +731,24 @@ func getSortedNodes(graph model.Graph) []model.Node {
 	return nodes
 }
 
+// updateNodes returns a copy of the graph with f applied to every node,
+// including the node copies stored inside edges.
+func updateNodes(graph model.Graph, f func(model.Node) model.Node) model.Graph {
+	nodes := make([]model.Node, 0, len(graph.Nodes))
+	for _, n := range graph.Nodes {
+		nodes = append(nodes, f(n))
+	}
+	edges := make([]model.Edge, 0, len(graph.Edges))
+	for _, e := range graph.Edges {
+		e.From = f(e.From)
+		e.To = f(e.To)
+		edges = append(edges, e)
+	}
+	graph.Nodes = nodes
+	graph.Edges = edges
+	return graph
+}
+
 func graphToNodes(graph model.Graph) map[uint64]model.Node {
 	nodes := make(map[uint64]model.Node)
 	for _, e := range graph.Edges {
@@ -30,6 +30,20 @@ func nodeInSlice(a model.Node, list []model.Node) bool {
 	return false
 }
 
+// edgesGraph builds a graph out of node ID pairs, one edge per pair.
+func edgesGraph(isDirected bool, pairs ...[2]uint64) model.Graph {
+	var graph model.Graph
+	for i, p := range pairs {
+		graph.Edges = append(graph.Edges, model.Edge{
+			ID:         uint64(i + 1),
+			From:       model.Node{ID: p[0]},
+			To:         model.Node{ID: p[1]},
+			IsDirected: isDirected,
+		})
+	}
+	return graph
+}
+
 func TestGraph_PlanarCheck(t *testing.T) {
 	type args struct {
 		graph model.Graph
@@ -0,0 +1,244 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+const (
+	// orderingSweeps is the number of down and up barycenter sweeps used to
+	// reduce crossings between layers.
+	orderingSweeps = 24
+	// maxLayeredVertices limits nodes and dummy nodes of hierarchical
+	// layouts, dense graphs need about n³/6 dummies.
+	maxLayeredVertices = 20000
+)
+
+var ErrTooManyVertices = errors.New("graph needs too many layered vertices")
+
+// sugiyama places nodes in layers following the steps of the Sugiyama
+// framework: arcs closing cycles are reversed, layers are assigned by
+// longest paths, arcs spanning several layers are split by dummy nodes and
+// nodes are ordered within layers by barycenters of their neighbours. Points
+// use layer numbers as y and positions in the layer, centered, as x. Graphs
+// needing more than maxLayeredVertices nodes and dummies are rejected with
+// ErrTooManyVertices.
+func sugiyama(graph model.Graph, adj adjacency) ([]point, error) {
+	n := len(adj.nodes)
+	succ := make([][]int, n)
+	seen := make(map[[2]int]struct{}, len(graph.Edges))
+	for _, e := range graph.Edges {
+		from, to := adj.index[e.From.ID], adj.index[e.To.ID]
+		if from == to {
+			continue
+		}
+		if _, ok := seen[[2]int{from, to}]; ok {
+			continue
+		}
+		seen[[2]int{from, to}] = struct{}{}
+		succ[from] = append(succ[from], to)
+	}
+	succ = acyclicArcs(succ)
+
+	layer := longestPathLayers(succ)
+
+	// Vertices past the real nodes are dummies, every arc between vertices
+	// of adjacent layers is kept as a pair of up and down neighbours.
+	vertexLayer := append([]int(nil), layer...)
+	var up, down [][]int
+	up = make([][]int, n)
+	down = make([][]int, n)
+	link := func(from, to int) {
+		down[from] = append(down[from], to)
+		up[to] = append(up[to], from)
+	}
+	for v := range succ {
+		for _, w := range succ[v] {
+			prev := v
+			if len(vertexLayer)+layer[w]-layer[v]-1 > maxLayeredVertices {
+				return nil, ErrTooManyVertices
+			}
+			for l := layer[v] + 1; l < layer[w]; l++ {
+				dummy := len(vertexLayer)
+				vertexLayer = append(vertexLayer, l)
+				up = append(up, nil)
+				down = append(down, nil)
+				link(prev, dummy)
+				prev = dummy
+			}
+			link(prev, w)
+		}
+	}
+
+	var layers [][]int
+	for v, l := range vertexLayer {
+		for len(layers) <= l {
+			layers = append(layers, nil)
+		}
+		layers[l] = append(layers[l], v)
+	}
+	position := make([]float64, len(vertexLayer))
+	place := func() {
+		for _, vs := range layers {
+			for i, v := range vs {
+				position[v] = float64(i)
+			}
+		}
+	}
+	place()
+
+	best := copyLayers(layers)
+	fewest := layerCrossings(layers, down, position)
+	for sweep := 0; sweep < orderingSweeps && fewest > 0; sweep++ {
+		for i := 1; i < len(layers); i++ {
+			orderByBarycenter(layers[i], up, position)
+			place()
+		}
+		for i := len(layers) - 2; i >= 0; i-- {
+			orderByBarycenter(layers[i], down, position)
+			place()
+		}
+		if c := layerCrossings(layers, down, position); c < fewest {
+			fewest = c
+			best = copyLayers(layers)
+		}
+	}
+
+	points := make([]point, n)
+	for l, vs := range best {
+		for i, v := range vs {
+			if v < n {
+				points[v] = point{x: float64(i) - float64(len(vs)-1)/2, y: float64(l)}
+			}
+		}
+	}
+	return points, nil
+}
+
+// acyclicArcs reverses arcs leading back to a node on the stack of a depth
+// first search, which leaves no cycles.
+func acyclicArcs(succ [][]int) [][]int {
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+	state := make([]int, len(succ))
+	res := make([][]int, len(succ))
+	var visit func(v int)
+	visit = func(v int) {
+		state[v] = onStack
+		for _, w := range succ[v] {
+			switch state[w] {
+			case onStack:
+				res[w] = append(res[w], v)
+			case unvisited:
+				res[v] = append(res[v], w)
+				visit(w)
+			default:
+				res[v] = append(res[v], w)
+			}
+		}
+		state[v] = done
+	}
+	for v := range succ {
+		if state[v] == unvisited {
+			visit(v)
+		}
+	}
+	return res
+}
+
+// longestPathLayers puts every node of an acyclic graph one layer below
+// the lowest of its predecessors, sources in layer 0.
+func longestPathLayers(succ [][]int) []int {
+	pred := make([][]int, len(succ))
+	for v, ws := range succ {
+		for _, w := range ws {
+			pred[w] = append(pred[w], v)
+		}
+	}
+	layer := make([]int, len(succ))
+	for v := range layer {
+		layer[v] = -1
+	}
+	var assign func(v int) int
+	assign = func(v int) int {
+		if layer[v] == -1 {
+			layer[v] = 0
+			for _, u := range pred[v] {
+				if l := assign(u) + 1; l > layer[v] {
+					layer[v] = l
+				}
+			}
+		}
+		return layer[v]
+	}
+	for v := range succ {
+		assign(v)
+	}
+	return layer
+}
+
+// orderByBarycenter sorts a layer by the mean position of neighbours in the
+// adjacent layer. Vertices without such neighbours keep their position.
+func orderByBarycenter(layer []int, neighbours [][]int, position []float64) {
+	barycenter := make(map[int]float64, len(layer))
+	for _, v := range layer {
+		if len(neighbours[v]) == 0 {
+			barycenter[v] = position[v]
+			continue
+		}
+		var sum float64
+		for _, w := range neighbours[v] {
+			sum += position[w]
+		}
+		barycenter[v] = sum / float64(len(neighbours[v]))
+	}
+	sort.SliceStable(layer, func(i, j int) bool {
+		return barycenter[layer[i]] < barycenter[layer[j]]
+	})
+}
+
+// layerCrossings counts pairs of arcs between the same adjacent layers
+// whose ends are in opposite orders. Arcs are visited in order of their
+// upper ends and a Fenwick tree over the lower layer counts earlier arcs
+// ending further right, as proposed by Barth, Jünger and Mutzel.
+func layerCrossings(layers [][]int, down [][]int, position []float64) int {
+	count := 0
+	for l := 0; l+1 < len(layers); l++ {
+		tree := make([]int, len(layers[l+1])+1)
+		inserted := 0
+		for _, v := range layers[l] {
+			ends := make([]int, 0, len(down[v]))
+			for _, w := range down[v] {
+				ends = append(ends, int(position[w]))
+			}
+			sort.Ints(ends)
+			for _, p := range ends {
+				// Earlier arcs ending at positions up to p don't cross.
+				count += inserted
+				for i := p + 1; i > 0; i -= i & -i {
+					count -= tree[i]
+				}
+			}
+			for _, p := range ends {
+				for i := p + 1; i < len(tree); i += i & -i {
+					tree[i]++
+				}
+				inserted++
+			}
+		}
+	}
+	return count
+}
+
+func copyLayers(layers [][]int) [][]int {
+	res := make([][]int, len(layers))
+	for i, vs := range layers {
+		res[i] = append([]int(nil), vs...)
+	}
+	return res
+}
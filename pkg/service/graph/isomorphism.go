@@ -0,0 +1,165 @@
+package graph
+
+import (
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+// Match maps pattern node IDs to the host node IDs they were matched with.
+type Match map[uint64]uint64
+
+// FindPattern reports every occurrence of pattern inside graph to found.
+// Induced occurrences additionally forbid host edges between matched nodes
+// that the pattern doesn't have. The search stops after limit matches
+// (0 means no limit) or as soon as found returns false.
+func (g Graph) FindPattern(graph, pattern model.Graph, induced bool, limit uint64, found func(Match) bool) {
+	host := newAdjacency(graph)
+	p := newAdjacency(pattern)
+	if len(p.nodes) == 0 || len(p.nodes) > len(host.nodes) {
+		return
+	}
+	s := patternSearch{
+		host:        host,
+		pattern:     p,
+		hostArcs:    host.arcCounts(),
+		patternArcs: p.arcCounts(),
+		order:       matchingOrder(p),
+		mapping:     make([]int, len(p.nodes)),
+		used:        make([]bool, len(host.nodes)),
+		induced:     induced,
+		limit:       limit,
+		found:       found,
+	}
+	s.extend(0)
+}
+
+type patternSearch struct {
+	host        adjacency
+	pattern     adjacency
+	hostArcs    []map[int]int
+	patternArcs []map[int]int
+	order       []int
+	mapping     []int
+	used        []bool
+	induced     bool
+	limit       uint64
+	reported    uint64
+	found       func(Match) bool
+	stopped     bool
+}
+
+func (s *patternSearch) extend(depth int) {
+	if depth == len(s.order) {
+		s.report()
+		return
+	}
+	p := s.order[depth]
+	for _, h := range s.candidates(depth) {
+		if s.stopped {
+			return
+		}
+		if !s.feasible(depth, h) {
+			continue
+		}
+		s.mapping[p] = h
+		s.used[h] = true
+		s.extend(depth + 1)
+		s.used[h] = false
+	}
+}
+
+// candidates narrows host nodes down to neighbours of an already matched
+// node whenever the pattern node is connected to one.
+func (s *patternSearch) candidates(depth int) []int {
+	p := s.order[depth]
+	for _, q := range s.order[:depth] {
+		var arcs []arc
+		if s.patternArcs[q][p] > 0 {
+			arcs = s.host.out[s.mapping[q]]
+		} else if s.patternArcs[p][q] > 0 {
+			arcs = s.host.in[s.mapping[q]]
+		} else {
+			continue
+		}
+		seen := make(map[int]struct{}, len(arcs))
+		res := make([]int, 0, len(arcs))
+		for _, a := range arcs {
+			if _, ok := seen[a.to]; !ok {
+				seen[a.to] = struct{}{}
+				res = append(res, a.to)
+			}
+		}
+		return res
+	}
+	res := make([]int, len(s.host.nodes))
+	for i := range res {
+		res[i] = i
+	}
+	return res
+}
+
+func (s *patternSearch) feasible(depth, h int) bool {
+	p := s.order[depth]
+	if s.used[h] ||
+		len(s.host.out[h]) < len(s.pattern.out[p]) ||
+		len(s.host.in[h]) < len(s.pattern.in[p]) {
+		return false
+	}
+	if !s.compatible(s.patternArcs[p][p], s.hostArcs[h][h]) {
+		return false
+	}
+	for _, q := range s.order[:depth] {
+		hq := s.mapping[q]
+		if !s.compatible(s.patternArcs[p][q], s.hostArcs[h][hq]) ||
+			!s.compatible(s.patternArcs[q][p], s.hostArcs[hq][h]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *patternSearch) compatible(patternArcs, hostArcs int) bool {
+	if hostArcs < patternArcs {
+		return false
+	}
+	return !s.induced || (patternArcs > 0) == (hostArcs > 0)
+}
+
+func (s *patternSearch) report() {
+	match := make(Match, len(s.mapping))
+	for p, h := range s.mapping {
+		match[s.pattern.nodes[p].ID] = s.host.nodes[h].ID
+	}
+	s.reported++
+	if !s.found(match) || (s.limit != 0 && s.reported >= s.limit) {
+		s.stopped = true
+	}
+}
+
+// matchingOrder orders pattern nodes so that every node, where possible, is
+// connected to one placed before it, preferring nodes of higher degree.
+func matchingOrder(p adjacency) []int {
+	order := make([]int, 0, len(p.nodes))
+	placed := make([]bool, len(p.nodes))
+	links := make([]int, len(p.nodes))
+	for len(order) < len(p.nodes) {
+		best := -1
+		for v := range p.nodes {
+			if placed[v] {
+				continue
+			}
+			if best == -1 || links[v] > links[best] ||
+				links[v] == links[best] && len(p.out[v])+len(p.in[v]) > len(p.out[best])+len(p.in[best]) {
+				best = v
+			}
+		}
+		placed[best] = true
+		order = append(order, best)
+		for _, a := range p.out[best] {
+			links[a.to]++
+		}
+		for _, a := range p.in[best] {
+			links[a.to]++
+		}
+	}
+	return order
+}
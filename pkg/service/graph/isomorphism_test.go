@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_FindPattern(t *testing.T) {
+	k4 := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{1, 4}, [2]uint64{2, 3}, [2]uint64{2, 4}, [2]uint64{3, 4})
+	cycle4 := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 4}, [2]uint64{4, 1})
+	triangle := edgesGraph(false, [2]uint64{10, 20}, [2]uint64{20, 30}, [2]uint64{30, 10})
+	path3 := edgesGraph(false, [2]uint64{10, 20}, [2]uint64{20, 30})
+
+	type args struct {
+		graph   model.Graph
+		pattern model.Graph
+		induced bool
+		limit   uint64
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "triangles in K4",
+			args: args{graph: k4, pattern: triangle},
+			want: 24,
+		},
+		{
+			name: "triangles in 4-cycle",
+			args: args{graph: cycle4, pattern: triangle},
+			want: 0,
+		},
+		{
+			name: "non-induced paths in K4",
+			args: args{graph: k4, pattern: path3},
+			want: 24,
+		},
+		{
+			name: "induced paths in K4",
+			args: args{graph: k4, pattern: path3, induced: true},
+			want: 0,
+		},
+		{
+			name: "induced paths in 4-cycle",
+			args: args{graph: cycle4, pattern: path3, induced: true},
+			want: 8,
+		},
+		{
+			name: "limit",
+			args: args{graph: k4, pattern: triangle, limit: 5},
+			want: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := Graph{}
+			var got int
+			g.FindPattern(tt.args.graph, tt.args.pattern, tt.args.induced, tt.args.limit, func(Match) bool {
+				got++
+				return true
+			})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGraph_FindPatternDirected(t *testing.T) {
+	host := edgesGraph(true, [2]uint64{1, 2}, [2]uint64{2, 3})
+	pattern := edgesGraph(true, [2]uint64{7, 8})
+
+	var got []Match
+	Graph{}.FindPattern(host, pattern, false, 0, func(m Match) bool {
+		got = append(got, m)
+		return true
+	})
+	assert.ElementsMatch(t, []Match{{7: 1, 8: 2}, {7: 2, 8: 3}}, got)
+}
@@ -0,0 +1,345 @@
+package graph
+
+import (
+	"math"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+// Layouts are fitted into a canvas of this size, keeping a margin free
+// along its borders.
+const (
+	canvasWidth  = 1000
+	canvasHeight = 800
+	canvasMargin = 50
+)
+
+type LayoutKind string
+
+const (
+	CircularLayout      LayoutKind = "circular"
+	ForceDirectedLayout LayoutKind = "forceDirected"
+	SpringLayout        LayoutKind = "spring"
+	HierarchicalLayout  LayoutKind = "hierarchical"
+	PlanarLayout        LayoutKind = "planar"
+	TreeLayout          LayoutKind = "tree"
+	GridLayout          LayoutKind = "grid"
+)
+
+type point struct {
+	x, y float64
+}
+
+// rect is an area of the canvas.
+type rect struct {
+	minX, minY, maxX, maxY float64
+}
+
+// canvas is the area layouts are drawn in.
+func canvas() rect {
+	return rect{
+		minX: canvasMargin,
+		minY: canvasMargin,
+		maxX: canvasWidth - canvasMargin,
+		maxY: canvasHeight - canvasMargin,
+	}
+}
+
+// Layout returns a copy of the graph with nodes moved to positions on the
+// canvas computed by the given algorithm:
+//   - circular: a circle in order of IDs;
+//   - forceDirected: the force simulation of Fruchterman and Reingold;
+//   - spring: the spring model of Kamada and Kawai, where springs have the
+//     length of shortest paths between nodes;
+//   - hierarchical: layers of the Sugiyama framework following edge
+//     directions, undirected edges going from their first node, failing
+//     with ErrTooManyVertices for graphs needing too many dummy nodes;
+//   - planar: a drawing without crossings with the method of de Fraysseix,
+//     Pach and Pollack, failing with ErrNotPlanar for graphs that aren't
+//     planar;
+//   - tree: layers by depth of a breadth first spanning forest;
+//   - grid: rows of a square grid in order of IDs.
+//
+// Other attributes of nodes are kept.
+func (g Graph) Layout(graph model.Graph, kind LayoutKind) (model.Graph, error) {
+	adj := newUndirectedAdjacency(graph)
+	var points []point
+	switch kind {
+	case CircularLayout:
+		return circularLayout(graph), nil
+	case TreeLayout:
+		return treeLayout(graph), nil
+	case GridLayout:
+		cols := int(math.Ceil(math.Sqrt(float64(len(adj.nodes)))))
+		rows := 0
+		if cols > 0 {
+			rows = (len(adj.nodes) + cols - 1) / cols
+		}
+		points = gridPoints(rows, cols)[:len(adj.nodes)]
+	case ForceDirectedLayout:
+		points = fit(fruchtermanReingold(adj), canvas(), true)
+	case SpringLayout:
+		points = fit(kamadaKawai(adj), canvas(), true)
+	case HierarchicalLayout:
+		layered, err := sugiyama(graph, adj)
+		if err != nil {
+			return model.Graph{}, err
+		}
+		points = fit(layered, canvas(), false)
+	case PlanarLayout:
+		var err error
+		points, err = planarPoints(adj)
+		if err != nil {
+			return model.Graph{}, err
+		}
+		points = fitGrid(points, canvas())
+	default:
+		return model.Graph{}, ErrUnknownKind
+	}
+	return setPositions(graph, positionsOf(adj.nodes, points)), nil
+}
+
+// setPositions moves nodes to the given points, rounded to the canvas grid.
+// Nodes without a point keep their position.
+func setPositions(graph model.Graph, positions map[uint64]point) model.Graph {
+	return updateNodes(graph, func(n model.Node) model.Node {
+		if p, ok := positions[n.ID]; ok {
+			n.X = uint64(math.Round(math.Max(p.x, 0)))
+			n.Y = uint64(math.Round(math.Max(p.y, 0)))
+		}
+		return n
+	})
+}
+
+// circularLayout places nodes in order of their IDs clockwise on the
+// largest circle fitting the canvas, starting at the top.
+func circularLayout(graph model.Graph) model.Graph {
+	nodes := graphNodes(graph)
+	return setPositions(graph, positionsOf(nodes, ring(len(nodes), ringRadius())))
+}
+
+// treeLayout places nodes in rows by their depth in a breadth first
+// spanning forest, rooting every component at its smallest ID. Every
+// parent is centered above its children and subtrees don't overlap.
+func treeLayout(graph model.Graph) model.Graph {
+	adj := newUndirectedAdjacency(graph)
+	return setPositions(graph, positionsOf(adj.nodes, fit(forestPoints(adj), canvas(), false)))
+}
+
+// forestPoints puts leaves of a breadth first spanning forest one unit
+// apart and depths one unit apart.
+func forestPoints(adj adjacency) []point {
+	n := len(adj.nodes)
+	children := make([][]int, n)
+	depth := make([]int, n)
+	visited := make([]bool, n)
+	var roots []int
+	for r := range adj.nodes {
+		if visited[r] {
+			continue
+		}
+		roots = append(roots, r)
+		visited[r] = true
+		queue := []int{r}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, w := range adj.out[v] {
+				if !visited[w.to] {
+					visited[w.to] = true
+					depth[w.to] = depth[v] + 1
+					children[v] = append(children[v], w.to)
+					queue = append(queue, w.to)
+				}
+			}
+		}
+	}
+
+	points := make([]point, n)
+	var next float64
+	var place func(v int)
+	place = func(v int) {
+		points[v].y = float64(depth[v])
+		if len(children[v]) == 0 {
+			points[v].x = next
+			next++
+			return
+		}
+		for _, c := range children[v] {
+			place(c)
+		}
+		points[v].x = (points[children[v][0]].x + points[children[v][len(children[v])-1]].x) / 2
+	}
+	for _, r := range roots {
+		place(r)
+	}
+	return points
+}
+
+// fit scales and moves points to fill the box. Unless keepAspect is set,
+// both directions are scaled independently. Points on a line along one of
+// the directions are centered in that direction.
+func fit(points []point, box rect, keepAspect bool) []point {
+	if len(points) == 0 {
+		return points
+	}
+	bounds := rect{minX: points[0].x, minY: points[0].y, maxX: points[0].x, maxY: points[0].y}
+	for _, p := range points {
+		bounds.minX, bounds.maxX = math.Min(bounds.minX, p.x), math.Max(bounds.maxX, p.x)
+		bounds.minY, bounds.maxY = math.Min(bounds.minY, p.y), math.Max(bounds.maxY, p.y)
+	}
+	scale := func(from, to float64) float64 {
+		if from < 1e-12 {
+			return math.Inf(1)
+		}
+		return to / from
+	}
+	sx := scale(bounds.maxX-bounds.minX, box.maxX-box.minX)
+	sy := scale(bounds.maxY-bounds.minY, box.maxY-box.minY)
+	if keepAspect {
+		sx = math.Min(sx, sy)
+		sy = sx
+	}
+	if math.IsInf(sx, 1) {
+		sx = 0
+	}
+	if math.IsInf(sy, 1) {
+		sy = 0
+	}
+	cx, cy := (bounds.minX+bounds.maxX)/2, (bounds.minY+bounds.maxY)/2
+	boxX, boxY := (box.minX+box.maxX)/2, (box.minY+box.maxY)/2
+	res := make([]point, len(points))
+	for i, p := range points {
+		res[i] = point{x: boxX + (p.x-cx)*sx, y: boxY + (p.y-cy)*sy}
+	}
+	return res
+}
+
+// simpleEdges lists every pair of distinct adjacent nodes once.
+func simpleEdges(adj adjacency) [][2]int {
+	var edges [][2]int
+	for v, neighbours := range adj.neighbourSets() {
+		for _, u := range sortedKeys(neighbours) {
+			if v < u {
+				edges = append(edges, [2]int{v, u})
+			}
+		}
+	}
+	return edges
+}
+
+// eachCrossing calls visit for pairs i < j of edges without a common node
+// whose segments cross, in order of edges. With at set to a node only pairs
+// with an edge at that node are visited, all other pairs stay the same when
+// the node moves; -1 visits every pair.
+func eachCrossing(edges [][2]int, points []point, at int, visit func(i, j int)) {
+	for i, e := range edges {
+		if at >= 0 && e[0] != at && e[1] != at {
+			continue
+		}
+		for j, f := range edges {
+			if at < 0 && j <= i || e[0] == f[0] || e[0] == f[1] || e[1] == f[0] || e[1] == f[1] {
+				continue
+			}
+			if !segmentsCross(points[e[0]], points[e[1]], points[f[0]], points[f[1]]) {
+				continue
+			}
+			if j < i {
+				visit(j, i)
+			} else {
+				visit(i, j)
+			}
+		}
+	}
+}
+
+// countCrossings counts the pairs visited by eachCrossing.
+func countCrossings(edges [][2]int, points []point, at int) int {
+	count := 0
+	eachCrossing(edges, points, at, func(i, j int) {
+		count++
+	})
+	return count
+}
+
+// segmentsCross reports whether segments ab and cd share a point. Segments
+// of zero length only cross segments passing through them.
+func segmentsCross(a, b, c, d point) bool {
+	o1, o2 := orientation(a, b, c), orientation(a, b, d)
+	o3, o4 := orientation(c, d, a), orientation(c, d, b)
+	if o1*o2 < 0 && o3*o4 < 0 {
+		return true
+	}
+	return o1 == 0 && onSegment(a, b, c) || o2 == 0 && onSegment(a, b, d) ||
+		o3 == 0 && onSegment(c, d, a) || o4 == 0 && onSegment(c, d, b)
+}
+
+// orientation is 1 when c lies to the left of the line from a to b, -1 when
+// to the right and 0 when on the line.
+func orientation(a, b, c point) int {
+	cross := (b.x-a.x)*(c.y-a.y) - (b.y-a.y)*(c.x-a.x)
+	switch {
+	case cross > 1e-9:
+		return 1
+	case cross < -1e-9:
+		return -1
+	}
+	return 0
+}
+
+// onSegment reports whether c, lying on the line through a and b, lies
+// between them.
+func onSegment(a, b, c point) bool {
+	return math.Min(a.x, b.x)-1e-9 <= c.x && c.x <= math.Max(a.x, b.x)+1e-9 &&
+		math.Min(a.y, b.y)-1e-9 <= c.y && c.y <= math.Max(a.y, b.y)+1e-9
+}
+
+// ring returns n points spread clockwise over a circle around the canvas
+// center, starting at the top.
+func ring(n int, r float64) []point {
+	cx, cy := float64(canvasWidth)/2, float64(canvasHeight)/2
+	points := make([]point, n)
+	for i := range points {
+		angle := 2*math.Pi*float64(i)/float64(n) - math.Pi/2
+		points[i] = point{x: cx + r*math.Cos(angle), y: cy + r*math.Sin(angle)}
+	}
+	return points
+}
+
+// ringRadius is the radius of the largest circle fitting the canvas.
+func ringRadius() float64 {
+	return math.Min(canvasWidth, canvasHeight)/2 - canvasMargin
+}
+
+// gridPoints returns rows*cols points row by row, with square cells as
+// large as the canvas allows, centered on it.
+func gridPoints(rows, cols int) []point {
+	cell := math.Inf(1)
+	if cols > 1 {
+		cell = float64(canvasWidth-2*canvasMargin) / float64(cols-1)
+	}
+	if rows > 1 {
+		cell = math.Min(cell, float64(canvasHeight-2*canvasMargin)/float64(rows-1))
+	}
+	if math.IsInf(cell, 1) {
+		cell = 0
+	}
+	x0 := (float64(canvasWidth) - cell*float64(cols-1)) / 2
+	y0 := (float64(canvasHeight) - cell*float64(rows-1)) / 2
+	points := make([]point, 0, rows*cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			points = append(points, point{x: x0 + cell*float64(j), y: y0 + cell*float64(i)})
+		}
+	}
+	return points
+}
+
+// positionsOf pairs nodes with points in order.
+func positionsOf(nodes []model.Node, points []point) map[uint64]point {
+	positions := make(map[uint64]point, len(nodes))
+	for i, n := range nodes {
+		positions[n.ID] = points[i]
+	}
+	return positions
+}
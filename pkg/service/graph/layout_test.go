@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/illfate2/graph-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// layoutPoints returns positions of nodes in the order of the adjacency
+// view, read from edge endpoints as well as from the node list.
+func layoutPoints(graph model.Graph) (adjacency, []point) {
+	adj := newUndirectedAdjacency(graph)
+	points := make([]point, len(adj.nodes))
+	for i, n := range adj.nodes {
+		points[i] = point{x: float64(n.X), y: float64(n.Y)}
+	}
+	return adj, points
+}
+
+func TestGraph_Layout(t *testing.T) {
+	wheel, err := Graph{}.Generate("wheel", WheelGenerator, GeneratorParams{N: 6})
+	require.NoError(t, err)
+	wheel.Nodes[0].Color = "red"
+	kinds := []LayoutKind{
+		CircularLayout, ForceDirectedLayout, SpringLayout, HierarchicalLayout, PlanarLayout, TreeLayout, GridLayout,
+	}
+	for _, kind := range kinds {
+		t.Run(string(kind), func(t *testing.T) {
+			got, err := Graph{}.Layout(wheel, kind)
+			require.NoError(t, err)
+			require.Len(t, got.Nodes, len(wheel.Nodes))
+			assert.Equal(t, "red", got.Nodes[0].Color)
+			positions := make(map[[2]uint64]struct{})
+			byID := make(map[uint64]model.Node, len(got.Nodes))
+			for _, n := range got.Nodes {
+				byID[n.ID] = n
+				assert.True(t, n.X >= canvasMargin && n.X <= canvasWidth-canvasMargin, "node %d x is %d", n.ID, n.X)
+				assert.True(t, n.Y >= canvasMargin && n.Y <= canvasHeight-canvasMargin, "node %d y is %d", n.ID, n.Y)
+				positions[[2]uint64{n.X, n.Y}] = struct{}{}
+			}
+			assert.Len(t, positions, len(got.Nodes), "nodes share a position")
+			for _, e := range got.Edges {
+				assert.Equal(t, [2]uint64{byID[e.From.ID].X, byID[e.From.ID].Y}, [2]uint64{e.From.X, e.From.Y})
+				assert.Equal(t, [2]uint64{byID[e.To.ID].X, byID[e.To.ID].Y}, [2]uint64{e.To.X, e.To.Y})
+			}
+
+			again, err := Graph{}.Layout(wheel, kind)
+			require.NoError(t, err)
+			assert.Equal(t, got, again)
+		})
+	}
+
+	_, err = Graph{}.Layout(wheel, "random")
+	assert.Equal(t, ErrUnknownKind, err)
+}
+
+func TestGraph_LayoutLayers(t *testing.T) {
+	tree := edgesGraph(false, [2]uint64{3, 1}, [2]uint64{1, 2}, [2]uint64{1, 4}, [2]uint64{4, 5})
+	got, err := Graph{}.Layout(tree, TreeLayout)
+	require.NoError(t, err)
+	_, points := layoutPoints(got)
+	assert.True(t, points[0].y < points[1].y)
+	assert.Equal(t, points[1].y, points[2].y)
+	assert.Equal(t, points[1].y, points[3].y)
+	assert.True(t, points[3].y < points[4].y)
+	// Children of the root are met in order 3, 2, 4, so the root is above 2.
+	assert.Equal(t, points[0].x, points[1].x)
+
+	dag := edgesGraph(true, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{1, 3}, [2]uint64{3, 4}, [2]uint64{4, 2})
+	got, err = Graph{}.Layout(dag, HierarchicalLayout)
+	require.NoError(t, err)
+	_, points = layoutPoints(got)
+	assert.True(t, points[0].y < points[1].y)
+	assert.True(t, points[1].y < points[2].y)
+	assert.True(t, points[2].y < points[3].y)
+}
+
+func TestGraph_LayoutHierarchicalTooLarge(t *testing.T) {
+	complete, err := Graph{}.Generate("complete", CompleteGenerator, GeneratorParams{N: 80})
+	require.NoError(t, err)
+	_, err = Graph{}.Layout(complete, HierarchicalLayout)
+	assert.Equal(t, ErrTooManyVertices, err)
+}
+
+func TestLayerCrossings(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for run := 0; run < 100; run++ {
+		var layers [][]int
+		vertices := 0
+		for l := rng.Intn(4) + 1; l > 0; l-- {
+			var vs []int
+			for i := rng.Intn(6) + 1; i > 0; i-- {
+				vs = append(vs, vertices)
+				vertices++
+			}
+			layers = append(layers, vs)
+		}
+		down := make([][]int, vertices)
+		position := make([]float64, vertices)
+		for l, vs := range layers {
+			for i, v := range vs {
+				position[v] = float64(i)
+				if l+1 == len(layers) {
+					continue
+				}
+				for _, w := range layers[l+1] {
+					if rng.Intn(2) == 0 {
+						down[v] = append(down[v], w)
+					}
+				}
+			}
+		}
+
+		want := 0
+		for _, vs := range layers {
+			var arcs [][2]float64
+			for _, v := range vs {
+				for _, w := range down[v] {
+					arcs = append(arcs, [2]float64{position[v], position[w]})
+				}
+			}
+			for i, a := range arcs {
+				for _, b := range arcs[i+1:] {
+					if (a[0]-b[0])*(a[1]-b[1]) < 0 {
+						want++
+					}
+				}
+			}
+		}
+		assert.Equal(t, want, layerCrossings(layers, down, position))
+	}
+}
+
+func TestGraph_LayoutGrid(t *testing.T) {
+	graph := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{3, 4}, [2]uint64{5, 5})
+	got, err := Graph{}.Layout(graph, GridLayout)
+	require.NoError(t, err)
+	_, points := layoutPoints(got)
+	rows := make(map[float64]int)
+	for _, p := range points {
+		rows[p.y]++
+	}
+	assert.Equal(t, map[float64]int{points[0].y: 3, points[3].y: 2}, rows)
+	assert.True(t, points[0].x < points[1].x && points[1].x < points[2].x)
+	assert.Equal(t, points[0].x, points[3].x)
+}
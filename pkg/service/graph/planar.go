@@ -0,0 +1,340 @@
+package graph
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrNotPlanar = errors.New("graph is not planar")
+
+// planarPoints draws a planar graph with straight edges and no crossings.
+// An embedding found by the left-right planarity test is connected,
+// biconnected and triangulated with extra edges, and the method of de
+// Fraysseix, Pach and Pollack places nodes of the triangulation on an
+// integer grid along a canonical ordering. Points have the first two nodes
+// of the ordering at the bottom.
+func planarPoints(adj adjacency) ([]point, error) {
+	n := len(adj.nodes)
+	neighbours := make([][]int, n)
+	for v, set := range adj.neighbourSets() {
+		neighbours[v] = sortedKeys(set)
+	}
+	emb, ok := planarEmbedding(neighbours)
+	if !ok {
+		return nil, ErrNotPlanar
+	}
+	if n < 4 {
+		points := []point{{x: 0, y: 0}, {x: 2, y: 0}, {x: 1, y: -1}}
+		return points[:n], nil
+	}
+
+	labels, count := adj.components()
+	representative := make([]int, count)
+	for v := n - 1; v >= 0; v-- {
+		representative[labels[v]] = v
+	}
+	for i := 1; i < count; i++ {
+		emb.addFirst(representative[i-1], representative[i])
+		emb.addFirst(representative[i], representative[i-1])
+	}
+	outer := triangulate(emb)
+	return shiftPoints(canonicalOrdering(emb, outer)), nil
+}
+
+// triangulate adds edges to a connected embedding until every face is
+// bounded by a cycle and every face but the largest one is a triangle. It
+// returns the nodes of the largest face.
+func triangulate(emb embedding) []int {
+	var faces [][]int
+	outer := 0
+	visited := make(map[[2]int]bool)
+	for v := range emb.first {
+		for _, w := range emb.neighbours(v) {
+			if face := biconnectFace(emb, v, w, visited); len(face) > 0 {
+				faces = append(faces, face)
+				if len(face) > len(faces[outer]) {
+					outer = len(faces) - 1
+				}
+			}
+		}
+	}
+	for i, face := range faces {
+		if i != outer {
+			triangulateFace(emb, face[0], face[1])
+		}
+	}
+	return faces[outer]
+}
+
+// biconnectFace walks the face on the left of the half edge from v to w,
+// adding an edge around every node met twice so that the face becomes a
+// cycle. It returns the nodes of the face, or nothing when the face was
+// walked before.
+func biconnectFace(emb embedding, v, w int, visited map[[2]int]bool) []int {
+	if visited[[2]int{v, w}] {
+		return nil
+	}
+	visited[[2]int{v, w}] = true
+	face := []int{v}
+	inFace := map[int]bool{v: true}
+	v1, v2 := v, w
+	_, v3 := emb.nextFaceHalfEdge(v1, v2)
+	for v2 != v || v3 != w {
+		if inFace[v2] {
+			emb.addCW(v1, v3, v2)
+			emb.addCCW(v3, v1, v2)
+			visited[[2]int{v2, v3}] = true
+			visited[[2]int{v3, v1}] = true
+			v2 = v1
+		} else {
+			inFace[v2] = true
+			face = append(face, v2)
+		}
+		v1 = v2
+		v2, v3 = emb.nextFaceHalfEdge(v2, v3)
+		visited[[2]int{v1, v2}] = true
+	}
+	return face
+}
+
+// triangulateFace adds chords from the face on the left of the half edge
+// from v1 to v2, skipping chords that already bound another face.
+func triangulateFace(emb embedding, v1, v2 int) {
+	_, v3 := emb.nextFaceHalfEdge(v1, v2)
+	_, v4 := emb.nextFaceHalfEdge(v2, v3)
+	if v1 == v2 || v1 == v3 {
+		return
+	}
+	for v1 != v4 {
+		if emb.hasEdge(v1, v3) {
+			v1, v2, v3 = v2, v3, v4
+		} else {
+			emb.addCW(v1, v3, v2)
+			emb.addCCW(v3, v1, v2)
+			v2, v3 = v3, v4
+		}
+		_, v4 = emb.nextFaceHalfEdge(v2, v3)
+	}
+}
+
+// canonicalStep is a node of a canonical ordering with its neighbours on
+// the outer face of the nodes before it, from left to right.
+type canonicalStep struct {
+	node    int
+	contour []int
+}
+
+// canonicalOrdering orders nodes of a triangulated embedding so that every
+// node after the first two lies on the outer face of the nodes up to it and
+// its earlier neighbours form a path on the outer face of the nodes before
+// it. Nodes are removed from the outer face from the last one, picking the
+// smallest node without chords other than the first two.
+func canonicalOrdering(emb embedding, outer []int) []canonicalStep {
+	n := len(emb.first)
+	v1, v2 := outer[0], outer[1]
+	chords := make([]int, n)
+	marked := make([]bool, n)
+	ready := make([]bool, n)
+	for _, v := range outer {
+		ready[v] = true
+	}
+
+	// Neighbours along the outer face, leaving out the edge from v1 to v2.
+	ccwNeighbour := make(map[int]int, len(outer))
+	previous := v2
+	for _, v := range outer[2:] {
+		ccwNeighbour[previous] = v
+		previous = v
+	}
+	ccwNeighbour[previous] = v1
+	cwNeighbour := make(map[int]int, len(outer))
+	previous = v1
+	for i := len(outer) - 1; i > 0; i-- {
+		cwNeighbour[previous] = outer[i]
+		previous = outer[i]
+	}
+	outerNeighbours := func(x, y int) bool {
+		ccw, hasCCW := ccwNeighbour[x]
+		cw, hasCW := cwNeighbour[x]
+		return hasCCW && ccw == y || hasCW && cw == y
+	}
+	onOuterFace := func(x int) bool {
+		_, ok := ccwNeighbour[x]
+		return !marked[x] && (ok || x == v1)
+	}
+
+	for _, v := range outer {
+		for _, w := range emb.neighbours(v) {
+			if onOuterFace(w) && !outerNeighbours(v, w) {
+				chords[v]++
+				ready[v] = false
+			}
+		}
+	}
+
+	order := make([]canonicalStep, n)
+	order[0] = canonicalStep{node: v1}
+	order[1] = canonicalStep{node: v2}
+	for k := n - 1; k > 1; k-- {
+		v := -1
+		for u, ok := range ready {
+			if ok && u != v1 && u != v2 {
+				v = u
+				break
+			}
+		}
+		ready[v] = false
+		marked[v] = true
+
+		wp, wq := -1, -1
+		for _, w := range emb.neighbours(v) {
+			if marked[w] || !onOuterFace(w) {
+				continue
+			}
+			switch {
+			case w == v1:
+				wp = w
+			case w == v2:
+				wq = w
+			case cwNeighbour[w] == v:
+				wp = w
+			default:
+				wq = w
+			}
+			if wp != -1 && wq != -1 {
+				break
+			}
+		}
+
+		contour := []int{wp}
+		for w := wp; w != wq; {
+			next := emb.ccw[v][w]
+			contour = append(contour, next)
+			cwNeighbour[w] = next
+			ccwNeighbour[next] = w
+			w = next
+		}
+
+		if len(contour) == 2 {
+			for _, w := range contour {
+				chords[w]--
+				if chords[w] == 0 {
+					ready[w] = true
+				}
+			}
+		} else {
+			inner := make(map[int]bool, len(contour)-2)
+			for _, w := range contour[1 : len(contour)-1] {
+				inner[w] = true
+			}
+			for _, w := range contour[1 : len(contour)-1] {
+				ready[w] = true
+				for _, x := range emb.neighbours(w) {
+					if onOuterFace(x) && !outerNeighbours(w, x) {
+						chords[w]++
+						ready[w] = false
+						if !inner[x] {
+							chords[x]++
+							ready[x] = false
+						}
+					}
+				}
+			}
+		}
+		order[k] = canonicalStep{node: v, contour: contour}
+	}
+	return order
+}
+
+// shiftPoints is the shift method of de Fraysseix, Pach and Pollack: every
+// node of the canonical ordering is put above its contour neighbours after
+// shifting the right part of the contour. Offsets are kept relative to
+// the parent in a binary tree over the contour, which makes every shift
+// constant time. Points lie on a (2n-4)×(n-2) grid, growing upwards.
+func shiftPoints(order []canonicalStep) []point {
+	n := len(order)
+	left := make([]int, n)
+	right := make([]int, n)
+	dx := make([]int, n)
+	y := make([]int, n)
+	for v := range left {
+		left[v], right[v] = -1, -1
+	}
+
+	v1, v2, v3 := order[0].node, order[1].node, order[2].node
+	dx[v2], dx[v3], y[v3] = 1, 1, 1
+	right[v1], right[v3] = v3, v2
+	for _, step := range order[3:] {
+		vk, contour := step.node, step.contour
+		wp, wp1 := contour[0], contour[1]
+		wq, wq1 := contour[len(contour)-1], contour[len(contour)-2]
+		dx[wp1]++
+		dx[wq]++
+		width := 0
+		for _, w := range contour[1:] {
+			width += dx[w]
+		}
+		dx[vk] = (width - y[wp] + y[wq]) / 2
+		y[vk] = (width + y[wp] + y[wq]) / 2
+		dx[wq] = width - dx[vk]
+		if len(contour) > 2 {
+			dx[wp1] -= dx[vk]
+		}
+
+		right[wp] = vk
+		right[vk] = wq
+		if len(contour) > 2 {
+			left[vk] = wp1
+			right[wq1] = -1
+		} else {
+			left[vk] = -1
+		}
+	}
+
+	points := make([]point, n)
+	points[v1] = point{x: 0, y: -float64(y[v1])}
+	stack := []int{v1}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, c := range []int{left[v], right[v]} {
+			if c != -1 {
+				points[c] = point{x: points[v].x + float64(dx[c]), y: -float64(y[c])}
+				stack = append(stack, c)
+			}
+		}
+	}
+	return points
+}
+
+// fitGrid fits points on an integer grid into the box like fit, but scales
+// by whole numbers as long as the grid fits, which keeps rounded positions
+// free of crossings.
+func fitGrid(points []point, box rect) []point {
+	if len(points) == 0 {
+		return points
+	}
+	bounds := rect{minX: points[0].x, minY: points[0].y, maxX: points[0].x, maxY: points[0].y}
+	for _, p := range points {
+		bounds.minX, bounds.maxX = math.Min(bounds.minX, p.x), math.Max(bounds.maxX, p.x)
+		bounds.minY, bounds.maxY = math.Min(bounds.minY, p.y), math.Max(bounds.maxY, p.y)
+	}
+	scale := func(from, to float64) float64 {
+		if from == 0 {
+			return 0
+		}
+		return math.Floor(to / from)
+	}
+	sx := scale(bounds.maxX-bounds.minX, box.maxX-box.minX)
+	sy := scale(bounds.maxY-bounds.minY, box.maxY-box.minY)
+	if bounds.maxX > bounds.minX && sx < 1 || bounds.maxY > bounds.minY && sy < 1 {
+		return fit(points, box, false)
+	}
+	x0 := math.Round((box.minX+box.maxX)/2 - (bounds.minX+bounds.maxX)/2*sx)
+	y0 := math.Round((box.minY+box.maxY)/2 - (bounds.minY+bounds.maxY)/2*sy)
+	res := make([]point, len(points))
+	for i, p := range points {
+		res[i] = point{x: x0 + p.x*sx, y: y0 + p.y*sy}
+	}
+	return res
+}
@@ -0,0 +1,168 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/illfate2/graph-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// triangulatedGridGraph keeps every edge of a grid with one diagonal per
+// cell with probability p, numbering nodes in random order. Such graphs are
+// planar but usually neither connected nor 3-connected.
+func triangulatedGridGraph(rng *rand.Rand, rows, cols int, p float64) model.Graph {
+	ids := rng.Perm(rows * cols)
+	id := func(i, j int) uint64 {
+		return uint64(ids[i*cols+j] + 1)
+	}
+	var pairs [][2]uint64
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if j+1 < cols && rng.Float64() < p {
+				pairs = append(pairs, [2]uint64{id(i, j), id(i, j+1)})
+			}
+			if i+1 < rows && rng.Float64() < p {
+				pairs = append(pairs, [2]uint64{id(i, j), id(i+1, j)})
+			}
+			if i+1 < rows && j+1 < cols && rng.Float64() < p {
+				pairs = append(pairs, [2]uint64{id(i, j), id(i+1, j+1)})
+			}
+		}
+	}
+	return edgesGraph(false, pairs...)
+}
+
+// assertPlanarDrawing checks that edges don't cross and nodes don't share
+// positions.
+func assertPlanarDrawing(t *testing.T, graph model.Graph) {
+	adj, points := layoutPoints(graph)
+	assert.Zero(t, countCrossings(simpleEdges(adj), points, -1))
+	positions := make(map[point]struct{}, len(points))
+	for _, p := range points {
+		positions[p] = struct{}{}
+	}
+	assert.Len(t, positions, len(points), "nodes share a position")
+}
+
+func TestGraph_LayoutPlanar(t *testing.T) {
+	cube, err := Graph{}.Generate("cube", HypercubeGenerator, GeneratorParams{N: 3})
+	require.NoError(t, err)
+	grid, err := Graph{}.Generate("grid", GridGenerator, GeneratorParams{N: 3, M: 4})
+	require.NoError(t, err)
+	wheel, err := Graph{}.Generate("wheel", WheelGenerator, GeneratorParams{N: 7})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		graph model.Graph
+	}{
+		{name: "cube", graph: cube},
+		{name: "grid", graph: grid},
+		{name: "wheel", graph: wheel},
+		{name: "single node", graph: model.Graph{Nodes: []model.Node{{ID: 1}}}},
+		{name: "single edge", graph: edgesGraph(false, [2]uint64{1, 2})},
+		{name: "triangle", graph: edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1})},
+		{
+			name:  "triangle with a leaf",
+			graph: edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1}, [2]uint64{3, 4}),
+		},
+		{
+			name: "bowtie",
+			graph: edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1},
+				[2]uint64{3, 4}, [2]uint64{4, 5}, [2]uint64{5, 3}),
+		},
+		{
+			name: "theta",
+			graph: edgesGraph(false, [2]uint64{1, 3}, [2]uint64{3, 2}, [2]uint64{1, 4}, [2]uint64{4, 2},
+				[2]uint64{1, 5}, [2]uint64{5, 2}),
+		},
+		{
+			name:  "tree",
+			graph: edgesGraph(false, [2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{1, 4}, [2]uint64{2, 5}, [2]uint64{2, 6}),
+		},
+		{
+			name: "components",
+			graph: edgesGraph(false,
+				[2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1},
+				[2]uint64{4, 5}, [2]uint64{5, 6}, [2]uint64{6, 7}, [2]uint64{7, 4}, [2]uint64{4, 6},
+				[2]uint64{8, 8}, [2]uint64{9, 10}, [2]uint64{9, 10},
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Graph{}.Layout(tt.graph, PlanarLayout)
+			require.NoError(t, err)
+			assertPlanarDrawing(t, got)
+		})
+	}
+}
+
+func TestGraph_LayoutPlanarRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		graph := triangulatedGridGraph(rng, 2+rng.Intn(10), 2+rng.Intn(10), rng.Float64())
+		got, err := Graph{}.Layout(graph, PlanarLayout)
+		require.NoError(t, err)
+		assertPlanarDrawing(t, got)
+	}
+}
+
+func TestGraph_LayoutNotPlanar(t *testing.T) {
+	k5, err := Graph{}.Generate("", CompleteGenerator, GeneratorParams{N: 5})
+	require.NoError(t, err)
+	k33, err := Graph{}.Generate("", CompleteBipartiteGenerator, GeneratorParams{N: 3, M: 3})
+	require.NoError(t, err)
+	petersen, err := Graph{}.Generate("", PetersenGenerator, GeneratorParams{})
+	require.NoError(t, err)
+	// K5 with two edges subdivided has few enough edges to pass the edge
+	// count bound.
+	subdivided := edgesGraph(false, [2]uint64{1, 6}, [2]uint64{6, 2}, [2]uint64{1, 3}, [2]uint64{1, 4},
+		[2]uint64{1, 5}, [2]uint64{2, 3}, [2]uint64{2, 4}, [2]uint64{2, 5}, [2]uint64{3, 4}, [2]uint64{3, 5},
+		[2]uint64{4, 7}, [2]uint64{7, 5})
+
+	for name, graph := range map[string]model.Graph{"k5": k5, "k33": k33, "petersen": petersen, "subdivided": subdivided} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Graph{}.Layout(graph, PlanarLayout)
+			assert.Equal(t, ErrNotPlanar, err)
+		})
+	}
+}
+
+func TestPlanarEmbedding(t *testing.T) {
+	// Faces of an embedding of a connected planar graph satisfy Euler's
+	// formula n - m + f = 2.
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 100; i++ {
+		graph := triangulatedGridGraph(rng, 2+rng.Intn(6), 2+rng.Intn(6), 0.5+rng.Float64()/2)
+		adj := newUndirectedAdjacency(graph)
+		if _, count := adj.components(); count != 1 {
+			continue
+		}
+		neighbours := make([][]int, len(adj.nodes))
+		m := 0
+		for v, set := range adj.neighbourSets() {
+			neighbours[v] = sortedKeys(set)
+			m += len(set)
+		}
+		emb, ok := planarEmbedding(neighbours)
+		require.True(t, ok)
+
+		faces := 0
+		visited := make(map[[2]int]bool)
+		for v := range neighbours {
+			for _, w := range emb.neighbours(v) {
+				if visited[[2]int{v, w}] {
+					continue
+				}
+				faces++
+				for a, b := v, w; !visited[[2]int{a, b}]; a, b = emb.nextFaceHalfEdge(a, b) {
+					visited[[2]int{a, b}] = true
+				}
+			}
+		}
+		assert.Equal(t, 2, len(neighbours)-m/2+faces)
+	}
+}
@@ -0,0 +1,189 @@
+package graph
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+type ProductKind string
+
+const (
+	CartesianProduct     ProductKind = "cartesian"
+	TensorProduct        ProductKind = "tensor"
+	StrongProduct        ProductKind = "strong"
+	LexicographicProduct ProductKind = "lexicographic"
+	RootedProduct        ProductKind = "rooted"
+)
+
+// productGap is the distance kept between copies of the second graph when
+// laying out a product.
+const productGap = 40
+
+// Product returns the product of two graphs. Node (a, x) gets ID
+// i*|V2|+j+1, where i and j are positions of a and x among nodes sorted by
+// ID, and edges are numbered in order of their first endpoint. The rooted
+// product roots copies of the second graph at its node with the smallest
+// ID. The product is directed when any of the graphs has directed edges.
+func (g Graph) Product(first, second model.Graph, kind ProductKind) (model.Graph, error) {
+	firstAdj, secondAdj := newAdjacency(first), newAdjacency(second)
+	firstSets, secondSets := firstAdj.neighbourSets(), secondAdj.neighbourSets()
+	n2 := len(secondAdj.nodes)
+
+	var neighbours func(a, x int) []int
+	switch kind {
+	case CartesianProduct:
+		neighbours = func(a, x int) []int {
+			res := make([]int, 0, len(firstSets[a])+len(secondSets[x]))
+			for y := range secondSets[x] {
+				res = append(res, a*n2+y)
+			}
+			for b := range firstSets[a] {
+				res = append(res, b*n2+x)
+			}
+			return res
+		}
+	case TensorProduct:
+		neighbours = func(a, x int) []int {
+			res := make([]int, 0, len(firstSets[a])*len(secondSets[x]))
+			for b := range firstSets[a] {
+				for y := range secondSets[x] {
+					res = append(res, b*n2+y)
+				}
+			}
+			return res
+		}
+	case StrongProduct:
+		neighbours = func(a, x int) []int {
+			res := make([]int, 0, (len(firstSets[a])+1)*(len(secondSets[x])+1))
+			for b := range firstSets[a] {
+				res = append(res, b*n2+x)
+				for y := range secondSets[x] {
+					res = append(res, b*n2+y)
+				}
+			}
+			for y := range secondSets[x] {
+				res = append(res, a*n2+y)
+			}
+			return res
+		}
+	case LexicographicProduct:
+		neighbours = func(a, x int) []int {
+			res := make([]int, 0, len(firstSets[a])*n2+len(secondSets[x]))
+			for b := range firstSets[a] {
+				for y := 0; y < n2; y++ {
+					res = append(res, b*n2+y)
+				}
+			}
+			for y := range secondSets[x] {
+				res = append(res, a*n2+y)
+			}
+			return res
+		}
+	case RootedProduct:
+		neighbours = func(a, x int) []int {
+			res := make([]int, 0, len(firstSets[a])+len(secondSets[x]))
+			for y := range secondSets[x] {
+				res = append(res, a*n2+y)
+			}
+			if x == 0 {
+				for b := range firstSets[a] {
+					res = append(res, b*n2)
+				}
+			}
+			return res
+		}
+	default:
+		return model.Graph{}, ErrUnknownKind
+	}
+
+	b := newBuilder(strings.TrimSpace(first.Name + " " + string(kind) + " " + second.Name))
+	x, y := productLayout(firstAdj.nodes, secondAdj.nodes)
+	for i := range firstAdj.nodes {
+		for j := range secondAdj.nodes {
+			b.addNode(model.Node{X: x[i][j], Y: y[i][j]})
+		}
+	}
+	isDirected := hasDirectedEdges(first) || hasDirectedEdges(second)
+	nodes := b.result().Nodes
+	for v := range nodes {
+		to := neighbours(v/n2, v%n2)
+		sort.Ints(to)
+		for i, u := range to {
+			if i > 0 && to[i-1] == u || !isDirected && u < v {
+				continue
+			}
+			b.connect(nodes[v], nodes[u], isDirected)
+		}
+	}
+	return b.result(), nil
+}
+
+// productLayout places a copy of the second graph's drawing at every node
+// of the first one, spreading the first drawing just enough for the copies
+// not to overlap.
+func productLayout(first, second []model.Node) (x, y [][]uint64) {
+	minX1, minY1, _, _ := bounds(first)
+	minX2, minY2, maxX2, maxY2 := bounds(second)
+	cell := maxX2 - minX2
+	if maxY2-minY2 > cell {
+		cell = maxY2 - minY2
+	}
+	cell += productGap
+
+	// The closest pair of first graph nodes, measured by the larger of the
+	// coordinate differences, must end up at least a cell apart.
+	var closest uint64
+	for i := range first {
+		for j := i + 1; j < len(first); j++ {
+			d := distance(first[i].X, first[j].X)
+			if dy := distance(first[i].Y, first[j].Y); dy > d {
+				d = dy
+			}
+			if d != 0 && (closest == 0 || d < closest) {
+				closest = d
+			}
+		}
+	}
+	factor := 1.0
+	if closest != 0 && closest < cell {
+		factor = float64(cell) / float64(closest)
+	}
+
+	x, y = make([][]uint64, len(first)), make([][]uint64, len(first))
+	for i, a := range first {
+		x[i], y[i] = make([]uint64, len(second)), make([]uint64, len(second))
+		for j, b := range second {
+			x[i][j] = minX1 + uint64(float64(a.X-minX1)*factor) + b.X - minX2
+			y[i][j] = minY1 + uint64(float64(a.Y-minY1)*factor) + b.Y - minY2
+		}
+	}
+	return x, y
+}
+
+// bounds returns the bounding box of node positions.
+func bounds(nodes []model.Node) (minX, minY, maxX, maxY uint64) {
+	for i, n := range nodes {
+		if i == 0 || n.X < minX {
+			minX = n.X
+		}
+		if i == 0 || n.Y < minY {
+			minY = n.Y
+		}
+		if n.X > maxX {
+			maxX = n.X
+		}
+		if n.Y > maxY {
+			maxY = n.Y
+		}
+	}
+	return minX, minY, maxX, maxY
+}
+
+func distance(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,108 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_Product(t *testing.T) {
+	k2 := edgesGraph(false, [2]uint64{1, 2})
+	p3 := edgesGraph(false, [2]uint64{10, 20}, [2]uint64{20, 30})
+
+	type args struct {
+		first  model.Graph
+		second model.Graph
+		kind   ProductKind
+	}
+	tests := []struct {
+		name string
+		args args
+		want [][2]uint64
+	}{
+		{
+			name: "cartesian",
+			args: args{first: k2, second: k2, kind: CartesianProduct},
+			want: [][2]uint64{{1, 2}, {1, 3}, {2, 4}, {3, 4}},
+		},
+		{
+			name: "tensor",
+			args: args{first: k2, second: k2, kind: TensorProduct},
+			want: [][2]uint64{{1, 4}, {2, 3}},
+		},
+		{
+			name: "strong",
+			args: args{first: k2, second: k2, kind: StrongProduct},
+			want: [][2]uint64{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}},
+		},
+		{
+			name: "lexicographic",
+			args: args{first: k2, second: p3, kind: LexicographicProduct},
+			want: [][2]uint64{
+				{1, 2}, {1, 4}, {1, 5}, {1, 6},
+				{2, 3}, {2, 4}, {2, 5}, {2, 6},
+				{3, 4}, {3, 5}, {3, 6},
+				{4, 5}, {5, 6},
+			},
+		},
+		{
+			name: "rooted",
+			args: args{first: k2, second: p3, kind: RootedProduct},
+			want: [][2]uint64{{1, 2}, {1, 4}, {2, 3}, {4, 5}, {5, 6}},
+		},
+		{
+			name: "directed tensor",
+			args: args{first: edgesGraph(true, [2]uint64{1, 2}), second: edgesGraph(true, [2]uint64{1, 2}), kind: TensorProduct},
+			want: [][2]uint64{{1, 4}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Graph{}.Product(tt.args.first, tt.args.second, tt.args.kind)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, edgePairs(got))
+			for i, e := range got.Edges {
+				assert.Equal(t, uint64(i+1), e.ID)
+			}
+		})
+	}
+}
+
+func TestGraph_ProductLayout(t *testing.T) {
+	first := edgesGraph(false, [2]uint64{1, 2})
+	first.Nodes = []model.Node{{ID: 1, X: 0, Y: 0}, {ID: 2, X: 10, Y: 0}}
+	second := edgesGraph(false, [2]uint64{1, 2})
+	second.Nodes = []model.Node{{ID: 1, X: 0, Y: 0}, {ID: 2, X: 0, Y: 100}}
+
+	got, err := Graph{}.Product(first, second, CartesianProduct)
+	require.NoError(t, err)
+	assert.Equal(t, []model.Node{
+		{ID: 1, X: 0, Y: 0},
+		{ID: 2, X: 0, Y: 100},
+		{ID: 3, X: 140, Y: 0},
+		{ID: 4, X: 140, Y: 100},
+	}, got.Nodes)
+}
+
+func TestGraph_Cartesian(t *testing.T) {
+	a1, a2 := model.Node{ID: 1, X: 0}, model.Node{ID: 2, X: 10}
+	first := model.Graph{ID: 7, Name: "first", Nodes: []model.Node{a1, a2},
+		Edges: []model.Edge{{ID: 1, From: a1, To: a2}}}
+	x1, x2 := model.Node{ID: 1, Y: 0}, model.Node{ID: 2, Y: 100}
+	second := model.Graph{ID: 8, Name: "second", Nodes: []model.Node{x1, x2},
+		Edges: []model.Edge{{ID: 1, From: x1, To: x2}}}
+
+	got := Graph{}.Cartesian(first, second)
+	assert.Equal(t, uint64(7), got.ID)
+	assert.Equal(t, "first", got.Name)
+	assert.Equal(t, []model.Node{
+		{ID: 0, X: 0, Y: 0},
+		{ID: 1, X: 0, Y: 100},
+		{ID: 2, X: 10, Y: 0},
+		{ID: 3, X: 10, Y: 100},
+	}, got.Nodes)
+	assert.Equal(t, [][2]uint64{{0, 2}, {0, 1}, {1, 3}, {2, 3}}, edgePairs(got))
+}
@@ -0,0 +1,155 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+var ErrCyclic = errors.New("graph has a cycle")
+
+// ReachabilityMatrix marks with 1 every node reachable from another one by
+// a path of at least one edge, so a node reaches itself only when it lies
+// on a cycle. Undirected edges can be walked both ways.
+func (g Graph) ReachabilityMatrix(graph model.Graph) AdjacencyMatrix {
+	adj := newAdjacency(graph)
+	reach := reachability(adj)
+	matrix := make(AdjacencyMatrix, len(adj.nodes))
+	for v, from := range adj.nodes {
+		row := make(map[model.Node]int, len(adj.nodes))
+		for u, to := range adj.nodes {
+			row[to] = 0
+			if reach[v][u] {
+				row[to] = 1
+			}
+		}
+		matrix[from] = row
+	}
+	return matrix
+}
+
+// TransitiveClosure adds a directed edge from every node to each node it
+// reaches and isn't connected to yet. New edges get IDs after the largest
+// existing one.
+func (g Graph) TransitiveClosure(graph model.Graph) model.Graph {
+	adj := newAdjacency(graph)
+	reach := reachability(adj)
+	res := graph
+	res.Nodes = adj.nodes
+	res.Edges = append([]model.Edge(nil), graph.Edges...)
+	var nextID uint64
+	for _, e := range graph.Edges {
+		if e.ID > nextID {
+			nextID = e.ID
+		}
+	}
+	sets := adj.neighbourSets()
+	for v := range adj.nodes {
+		for u := range adj.nodes {
+			if _, ok := sets[v][u]; ok || u == v || !reach[v][u] {
+				continue
+			}
+			nextID++
+			res.Edges = append(res.Edges, model.Edge{
+				ID:         nextID,
+				From:       adj.nodes[v],
+				To:         adj.nodes[u],
+				IsDirected: true,
+			})
+		}
+	}
+	return res
+}
+
+// TransitiveReduction removes every edge u->v of a directed acyclic graph
+// when v is also reachable from u by a longer path, along with parallel
+// copies of kept edges. Undirected edges count as cycles.
+func (g Graph) TransitiveReduction(graph model.Graph) (model.Graph, error) {
+	adj := newAdjacency(graph)
+	order, ok := topologicalOrder(adj)
+	if !ok {
+		return model.Graph{}, ErrCyclic
+	}
+	// reach[v] holds nodes reachable from v, filled in reverse topological
+	// order so successors are always done first.
+	reach := make([][]bool, len(adj.nodes))
+	for i := len(order) - 1; i >= 0; i-- {
+		v := order[i]
+		reach[v] = make([]bool, len(adj.nodes))
+		for _, w := range adj.out[v] {
+			reach[v][w.to] = true
+			for u, ok := range reach[w.to] {
+				if ok {
+					reach[v][u] = true
+				}
+			}
+		}
+	}
+
+	res := graph
+	res.Nodes = adj.nodes
+	res.Edges = nil
+	kept := make(map[[2]int]struct{})
+	for _, e := range graph.Edges {
+		from, to := adj.index[e.From.ID], adj.index[e.To.ID]
+		if _, ok := kept[[2]int{from, to}]; ok {
+			continue
+		}
+		redundant := false
+		for _, w := range adj.out[from] {
+			if w.to != to && reach[w.to][to] {
+				redundant = true
+				break
+			}
+		}
+		if !redundant {
+			kept[[2]int{from, to}] = struct{}{}
+			res.Edges = append(res.Edges, e)
+		}
+	}
+	return res, nil
+}
+
+// reachability returns which nodes every node reaches by at least one arc.
+func reachability(adj adjacency) [][]bool {
+	reach := make([][]bool, len(adj.nodes))
+	for v := range adj.nodes {
+		reach[v] = make([]bool, len(adj.nodes))
+		stack := []int{v}
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, w := range adj.out[u] {
+				if !reach[v][w.to] {
+					reach[v][w.to] = true
+					stack = append(stack, w.to)
+				}
+			}
+		}
+	}
+	return reach
+}
+
+// topologicalOrder is Kahn's algorithm. It reports false when the view has
+// a cycle, self loops and undirected edges included.
+func topologicalOrder(adj adjacency) ([]int, bool) {
+	indegree := make([]int, len(adj.nodes))
+	for v := range adj.nodes {
+		indegree[v] = len(adj.in[v])
+	}
+	order := make([]int, 0, len(adj.nodes))
+	for v := range adj.nodes {
+		if indegree[v] == 0 {
+			order = append(order, v)
+		}
+	}
+	for i := 0; i < len(order); i++ {
+		for _, w := range adj.out[order[i]] {
+			indegree[w.to]--
+			if indegree[w.to] == 0 {
+				order = append(order, w.to)
+			}
+		}
+	}
+	return order, len(order) == len(adj.nodes)
+}
@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_ReachabilityMatrix(t *testing.T) {
+	graph := edgesGraph(true, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 2})
+
+	got := Graph{}.ReachabilityMatrix(graph)
+	assert.Equal(t, "   1 2 3 \n1: 0 1 1 \n2: 0 1 1 \n3: 0 1 1 \n", got.String())
+}
+
+func TestGraph_TransitiveClosure(t *testing.T) {
+	graph := edgesGraph(true, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 4})
+
+	got := Graph{}.TransitiveClosure(graph)
+	assert.Equal(t, [][2]uint64{{1, 2}, {2, 3}, {3, 4}, {1, 3}, {1, 4}, {2, 4}}, edgePairs(got))
+	for i, e := range got.Edges {
+		assert.Equal(t, uint64(i+1), e.ID)
+		assert.True(t, e.IsDirected)
+	}
+}
+
+func TestGraph_TransitiveReduction(t *testing.T) {
+	tests := []struct {
+		name    string
+		graph   model.Graph
+		want    [][2]uint64
+		wantErr error
+	}{
+		{
+			name: "redundant arcs",
+			graph: edgesGraph(true,
+				[2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{2, 3}, [2]uint64{3, 4}, [2]uint64{1, 4}, [2]uint64{3, 4},
+			),
+			want: [][2]uint64{{1, 2}, {2, 3}, {3, 4}},
+		},
+		{
+			name:  "diamond",
+			graph: edgesGraph(true, [2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{2, 4}, [2]uint64{3, 4}),
+			want:  [][2]uint64{{1, 2}, {1, 3}, {2, 4}, {3, 4}},
+		},
+		{
+			name:    "cycle",
+			graph:   edgesGraph(true, [2]uint64{1, 2}, [2]uint64{2, 1}),
+			wantErr: ErrCyclic,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Graph{}.TransitiveReduction(tt.graph)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, edgePairs(got))
+		})
+	}
+}
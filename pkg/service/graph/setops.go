@@ -0,0 +1,188 @@
+package graph
+
+import (
+	"strings"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+type SetOperation string
+
+const (
+	UnionOperation               SetOperation = "union"
+	IntersectionOperation        SetOperation = "intersection"
+	DifferenceOperation          SetOperation = "difference"
+	SymmetricDifferenceOperation SetOperation = "symmetricDifference"
+	JoinOperation                SetOperation = "join"
+)
+
+type MatchBy string
+
+const (
+	MatchByID   MatchBy = "id"
+	MatchByName MatchBy = "name"
+)
+
+// nodeKey identifies a node across two graphs. Unnamed nodes never match
+// by name, so they are told apart by the graph they come from.
+type nodeKey struct {
+	id    uint64
+	name  string
+	graph int
+}
+
+type edgeKey struct {
+	from, to   nodeKey
+	isDirected bool
+}
+
+// Combine applies a set operation to two graphs whose nodes are matched by
+// ID or by name. Edges match when their matched endpoints and directions
+// do, so parallel edges collapse into one. Difference keeps all nodes of
+// the first graph and join is the disjoint union plus an undirected edge
+// between every pair of nodes from different graphs. Nodes of one graph
+// sharing a name become a single node when matching by name. The result
+// gets fresh IDs; matched nodes and edges keep attributes of the first
+// graph.
+func (g Graph) Combine(first, second model.Graph, op SetOperation, matchBy MatchBy) (model.Graph, error) {
+	if matchBy != MatchByID && matchBy != MatchByName {
+		return model.Graph{}, ErrUnknownKind
+	}
+	b := newBuilder(strings.TrimSpace(first.Name + " " + string(op) + " " + second.Name))
+	if op == JoinOperation {
+		join(b, first, second)
+		return b.result(), nil
+	}
+
+	keyOf := func(n model.Node, graph int) nodeKey {
+		if matchBy == MatchByID {
+			return nodeKey{id: n.ID}
+		}
+		if n.Name == "" {
+			return nodeKey{id: n.ID, graph: graph}
+		}
+		return nodeKey{name: n.Name}
+	}
+	firstNodes, secondNodes := graphNodes(first), graphNodes(second)
+	firstKeys := make(map[uint64]nodeKey, len(firstNodes))
+	inFirst := make(map[nodeKey]struct{}, len(firstNodes))
+	for _, n := range firstNodes {
+		firstKeys[n.ID] = keyOf(n, 1)
+		inFirst[firstKeys[n.ID]] = struct{}{}
+	}
+	secondKeys := make(map[uint64]nodeKey, len(secondNodes))
+	inSecond := make(map[nodeKey]struct{}, len(secondNodes))
+	for _, n := range secondNodes {
+		secondKeys[n.ID] = keyOf(n, 2)
+		inSecond[secondKeys[n.ID]] = struct{}{}
+	}
+	firstEdges := keyEdges(first, firstKeys)
+	secondEdges := keyEdges(second, secondKeys)
+
+	var keepNode func(key nodeKey, fromFirst bool) bool
+	var keepEdge func(key edgeKey, fromFirst bool) bool
+	switch op {
+	case UnionOperation:
+		keepNode = func(nodeKey, bool) bool { return true }
+		keepEdge = func(edgeKey, bool) bool { return true }
+	case IntersectionOperation:
+		keepNode = func(key nodeKey, fromFirst bool) bool {
+			_, ok := inSecond[key]
+			return fromFirst && ok
+		}
+		keepEdge = func(key edgeKey, fromFirst bool) bool {
+			_, ok := secondEdges[key]
+			return fromFirst && ok
+		}
+	case DifferenceOperation:
+		keepNode = func(key nodeKey, fromFirst bool) bool { return fromFirst }
+		keepEdge = func(key edgeKey, fromFirst bool) bool {
+			_, ok := secondEdges[key]
+			return fromFirst && !ok
+		}
+	case SymmetricDifferenceOperation:
+		keepNode = func(nodeKey, bool) bool { return true }
+		keepEdge = func(key edgeKey, fromFirst bool) bool {
+			if fromFirst {
+				_, ok := secondEdges[key]
+				return !ok
+			}
+			_, ok := firstEdges[key]
+			return !ok
+		}
+	default:
+		return model.Graph{}, ErrUnknownKind
+	}
+
+	nodes := make(map[nodeKey]model.Node)
+	for _, n := range firstNodes {
+		key := firstKeys[n.ID]
+		if _, ok := nodes[key]; !ok && keepNode(key, true) {
+			nodes[key] = b.addNode(n)
+		}
+	}
+	for _, n := range secondNodes {
+		key := secondKeys[n.ID]
+		if _, ok := nodes[key]; !ok && keepNode(key, false) {
+			nodes[key] = b.addNode(n)
+		}
+	}
+	added := make(map[edgeKey]struct{})
+	addEdges := func(graph model.Graph, keys map[uint64]nodeKey, fromFirst bool) {
+		for _, e := range graph.Edges {
+			key := newEdgeKey(e, keys)
+			if _, ok := added[key]; ok || !keepEdge(key, fromFirst) {
+				continue
+			}
+			added[key] = struct{}{}
+			b.addEdge(e, nodes[keys[e.From.ID]], nodes[keys[e.To.ID]])
+		}
+	}
+	addEdges(first, firstKeys, true)
+	addEdges(second, secondKeys, false)
+	return b.result(), nil
+}
+
+func join(b *builder, first, second model.Graph) {
+	firstNodes, secondNodes := copyNodes(b, first), copyNodes(b, second)
+	firstIndex, secondIndex := newAdjacency(first).index, newAdjacency(second).index
+	for _, e := range first.Edges {
+		b.addEdge(e, firstNodes[firstIndex[e.From.ID]], firstNodes[firstIndex[e.To.ID]])
+	}
+	for _, e := range second.Edges {
+		b.addEdge(e, secondNodes[secondIndex[e.From.ID]], secondNodes[secondIndex[e.To.ID]])
+	}
+	for _, u := range firstNodes {
+		for _, v := range secondNodes {
+			b.connect(u, v, false)
+		}
+	}
+}
+
+func keyEdges(graph model.Graph, keys map[uint64]nodeKey) map[edgeKey]struct{} {
+	edges := make(map[edgeKey]struct{}, len(graph.Edges))
+	for _, e := range graph.Edges {
+		edges[newEdgeKey(e, keys)] = struct{}{}
+	}
+	return edges
+}
+
+// newEdgeKey orders endpoints of undirected edges, so both directions of
+// an undirected edge get the same key.
+func newEdgeKey(e model.Edge, keys map[uint64]nodeKey) edgeKey {
+	key := edgeKey{from: keys[e.From.ID], to: keys[e.To.ID], isDirected: e.IsDirected}
+	if !e.IsDirected && nodeKeyLess(key.to, key.from) {
+		key.from, key.to = key.to, key.from
+	}
+	return key
+}
+
+func nodeKeyLess(a, b nodeKey) bool {
+	if a.name != b.name {
+		return a.name < b.name
+	}
+	if a.id != b.id {
+		return a.id < b.id
+	}
+	return a.graph < b.graph
+}
@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_Combine(t *testing.T) {
+	first := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3})
+	second := edgesGraph(false, [2]uint64{3, 2}, [2]uint64{3, 4})
+
+	tests := []struct {
+		name  string
+		op    SetOperation
+		nodes int
+		want  [][2]uint64
+	}{
+		{
+			name:  "union",
+			op:    UnionOperation,
+			nodes: 4,
+			want:  [][2]uint64{{1, 2}, {2, 3}, {3, 4}},
+		},
+		{
+			name:  "intersection",
+			op:    IntersectionOperation,
+			nodes: 2,
+			want:  [][2]uint64{{1, 2}},
+		},
+		{
+			name:  "difference",
+			op:    DifferenceOperation,
+			nodes: 3,
+			want:  [][2]uint64{{1, 2}},
+		},
+		{
+			name:  "symmetric difference",
+			op:    SymmetricDifferenceOperation,
+			nodes: 4,
+			want:  [][2]uint64{{1, 2}, {3, 4}},
+		},
+		{
+			name:  "join",
+			op:    JoinOperation,
+			nodes: 6,
+			want: [][2]uint64{
+				{1, 2}, {2, 3}, {5, 4}, {5, 6},
+				{1, 4}, {1, 5}, {1, 6}, {2, 4}, {2, 5}, {2, 6}, {3, 4}, {3, 5}, {3, 6},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Graph{}.Combine(first, second, tt.op, MatchByID)
+			require.NoError(t, err)
+			assert.Len(t, got.Nodes, tt.nodes)
+			assert.Equal(t, tt.want, edgePairs(got))
+		})
+	}
+}
+
+func TestGraph_CombineByName(t *testing.T) {
+	first := edgesGraph(false, [2]uint64{1, 2})
+	first.Nodes = []model.Node{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+	second := edgesGraph(false, [2]uint64{7, 8}, [2]uint64{8, 9})
+	second.Nodes = []model.Node{{ID: 7, Name: "b"}, {ID: 8, Name: "a"}, {ID: 9}}
+
+	got, err := Graph{}.Combine(first, second, IntersectionOperation, MatchByName)
+	require.NoError(t, err)
+	assert.Equal(t, []model.Node{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, got.Nodes)
+	assert.Equal(t, [][2]uint64{{1, 2}}, edgePairs(got))
+
+	got, err = Graph{}.Combine(first, second, UnionOperation, MatchByName)
+	require.NoError(t, err)
+	assert.Len(t, got.Nodes, 3)
+	assert.Equal(t, [][2]uint64{{1, 2}, {1, 3}}, edgePairs(got))
+}
+
+func TestGraph_CombineByNameDuplicates(t *testing.T) {
+	first := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{3, 4})
+	first.Nodes = []model.Node{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "a"}, {ID: 4, Name: "c"}}
+	second := edgesGraph(false, [2]uint64{7, 8})
+	second.Nodes = []model.Node{{ID: 7, Name: "a"}, {ID: 8, Name: "c"}}
+
+	got, err := Graph{}.Combine(first, second, UnionOperation, MatchByName)
+	require.NoError(t, err)
+	assert.Equal(t, []model.Node{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}, got.Nodes)
+	assert.Equal(t, [][2]uint64{{1, 2}, {1, 3}}, edgePairs(got))
+}
@@ -0,0 +1,151 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+var ErrNodeNotFound = errors.New("node not found")
+
+// SpanningTreeCount is the number of spanning trees, or the sum of their
+// weight products for weighted graphs. Exact is false when the value was
+// computed in floating point because some weight isn't an integer.
+type SpanningTreeCount struct {
+	Count string `json:"count"`
+	Exact bool   `json:"exact"`
+}
+
+// SpanningTreeCount counts spanning trees of the graph with Kirchhoff's
+// matrix tree theorem. Edge directions and self loops are ignored and
+// negative weights are rejected.
+func (g Graph) SpanningTreeCount(graph model.Graph) (SpanningTreeCount, error) {
+	if err := checkWeights(graph); err != nil {
+		return SpanningTreeCount{}, err
+	}
+	adj := newUndirectedAdjacency(graph)
+	if len(adj.nodes) == 0 {
+		return SpanningTreeCount{Count: "0", Exact: true}, nil
+	}
+	return reducedLaplacianDeterminant(laplacian(adj), 0), nil
+}
+
+// ArborescenceCount counts spanning arborescences with all edges directed
+// away from the root, using Tutte's directed version of the theorem.
+// Undirected edges may be used in either direction.
+func (g Graph) ArborescenceCount(graph model.Graph, root uint64) (SpanningTreeCount, error) {
+	if err := checkWeights(graph); err != nil {
+		return SpanningTreeCount{}, err
+	}
+	adj := newAdjacency(graph)
+	r, ok := adj.index[root]
+	if !ok {
+		return SpanningTreeCount{}, ErrNodeNotFound
+	}
+	return reducedLaplacianDeterminant(laplacian(adj), r), nil
+}
+
+// laplacian returns the in-degree Laplacian D-A, which is the usual
+// Laplacian for undirected views.
+func laplacian(adj adjacency) [][]float64 {
+	l := make([][]float64, len(adj.nodes))
+	for v := range l {
+		l[v] = make([]float64, len(adj.nodes))
+	}
+	for v := range adj.nodes {
+		for _, w := range adj.out[v] {
+			if w.to == v {
+				continue
+			}
+			l[w.to][w.to] += w.weight
+			l[v][w.to] -= w.weight
+		}
+	}
+	return l
+}
+
+// reducedLaplacianDeterminant drops the given row and column and returns
+// the determinant of what is left, exactly when all entries are integers.
+func reducedLaplacianDeterminant(l [][]float64, skip int) SpanningTreeCount {
+	n := len(l) - 1
+	reduced := make([][]float64, 0, n)
+	integer := true
+	for i := range l {
+		if i == skip {
+			continue
+		}
+		row := make([]float64, 0, n)
+		for j, v := range l[i] {
+			if j == skip {
+				continue
+			}
+			if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+				integer = false
+			}
+			row = append(row, v)
+		}
+		reduced = append(reduced, row)
+	}
+	if n == 0 {
+		return SpanningTreeCount{Count: "1", Exact: true}
+	}
+
+	if integer {
+		m := make([][]*big.Int, n)
+		for i := range reduced {
+			m[i] = make([]*big.Int, n)
+			for j, v := range reduced[i] {
+				m[i][j] = big.NewInt(int64(v))
+			}
+		}
+		return SpanningTreeCount{Count: bareissDeterminant(m).String(), Exact: true}
+	}
+
+	dense := mat.NewDense(n, n, nil)
+	for i := range reduced {
+		dense.SetRow(i, reduced[i])
+	}
+	return SpanningTreeCount{Count: strconv.FormatFloat(mat.Det(dense), 'g', -1, 64)}
+}
+
+// bareissDeterminant is fraction free Gaussian elimination: every division
+// is exact, so intermediate values stay integers. The matrix is modified.
+func bareissDeterminant(m [][]*big.Int) *big.Int {
+	n := len(m)
+	sign := 1
+	prev := big.NewInt(1)
+	for k := 0; k < n-1; k++ {
+		if m[k][k].Sign() == 0 {
+			pivot := -1
+			for i := k + 1; i < n; i++ {
+				if m[i][k].Sign() != 0 {
+					pivot = i
+					break
+				}
+			}
+			if pivot == -1 {
+				return big.NewInt(0)
+			}
+			m[k], m[pivot] = m[pivot], m[k]
+			sign = -sign
+		}
+		for i := k + 1; i < n; i++ {
+			for j := k + 1; j < n; j++ {
+				a := new(big.Int).Mul(m[i][j], m[k][k])
+				b := new(big.Int).Mul(m[i][k], m[k][j])
+				m[i][j] = a.Sub(a, b).Quo(a, prev)
+			}
+		}
+		prev = m[k][k]
+	}
+	det := new(big.Int).Set(m[n-1][n-1])
+	if sign < 0 {
+		det.Neg(det)
+	}
+	return det
+}
@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_SpanningTreeCount(t *testing.T) {
+	k4 := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{1, 4}, [2]uint64{2, 3}, [2]uint64{2, 4}, [2]uint64{3, 4})
+	var k10 model.Graph
+	for i := uint64(1); i <= 10; i++ {
+		for j := i + 1; j <= 10; j++ {
+			k10.Edges = append(k10.Edges, model.Edge{From: model.Node{ID: i}, To: model.Node{ID: j}})
+		}
+	}
+
+	tests := []struct {
+		name  string
+		graph model.Graph
+		want  SpanningTreeCount
+	}{
+		{
+			name:  "K4",
+			graph: k4,
+			want:  SpanningTreeCount{Count: "16", Exact: true},
+		},
+		{
+			name:  "K10",
+			graph: k10,
+			want:  SpanningTreeCount{Count: "100000000", Exact: true},
+		},
+		{
+			name:  "disconnected",
+			graph: edgesGraph(false, [2]uint64{1, 2}, [2]uint64{3, 4}),
+			want:  SpanningTreeCount{Count: "0", Exact: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Graph{}.SpanningTreeCount(tt.graph)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGraph_SpanningTreeCountWeighted(t *testing.T) {
+	graph := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1})
+	graph.Edges[0].Weight = 0.5
+	graph.Edges[1].Weight = 2
+	graph.Edges[2].Weight = 4
+
+	got, err := Graph{}.SpanningTreeCount(graph)
+	require.NoError(t, err)
+	assert.False(t, got.Exact)
+	count, err := strconv.ParseFloat(got.Count, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5*2+2*4+4*0.5, count, 1e-9)
+
+	graph.Edges[0].Weight = -0.5
+	_, err = Graph{}.SpanningTreeCount(graph)
+	assert.Equal(t, ErrNegativeWeight, err)
+	_, err = Graph{}.ArborescenceCount(graph, 1)
+	assert.Equal(t, ErrNegativeWeight, err)
+}
+
+func TestGraph_ArborescenceCount(t *testing.T) {
+	// 1->2, 1->3, 2->3, 3->2: arborescences from 1 are {12,13}, {12,23}, {13,32}.
+	graph := edgesGraph(true, [2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{2, 3}, [2]uint64{3, 2})
+
+	got, err := Graph{}.ArborescenceCount(graph, 1)
+	require.NoError(t, err)
+	assert.Equal(t, SpanningTreeCount{Count: "3", Exact: true}, got)
+
+	got, err = Graph{}.ArborescenceCount(graph, 2)
+	require.NoError(t, err)
+	assert.Equal(t, SpanningTreeCount{Count: "0", Exact: true}, got)
+
+	_, err = Graph{}.ArborescenceCount(graph, 42)
+	assert.Equal(t, ErrNodeNotFound, err)
+}
@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+
+	"gonum.org/v1/gonum/graph/spectral"
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+var ErrNoConvergence = errors.New("eigen decomposition didn't converge")
+
+type Spectrum struct {
+	Adjacency             []float64          `json:"adjacency"`
+	Laplacian             []float64          `json:"laplacian"`
+	NormalizedLaplacian   []float64          `json:"normalizedLaplacian"`
+	AlgebraicConnectivity float64            `json:"algebraicConnectivity"`
+	FiedlerVector         map[uint64]float64 `json:"fiedlerVector"`
+}
+
+// Spectrum returns eigenvalues of the adjacency matrix, the Laplacian and
+// the normalized Laplacian of the underlying simple graph in ascending
+// order. The Fiedler vector is the Laplacian eigenvector of the algebraic
+// connectivity, signed so that its first non-zero entry is positive.
+func (g Graph) Spectrum(graph model.Graph) (Spectrum, error) {
+	undirected := toUndirectedGraph(graph)
+	if undirected.Nodes().Len() == 0 {
+		return Spectrum{}, nil
+	}
+
+	laplacian := spectral.NewLaplacian(undirected)
+	n := len(laplacian.Nodes)
+	adjacency := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			adjacency.SetSym(i, j, -laplacian.At(i, j))
+		}
+	}
+
+	var (
+		res   Spectrum
+		eigen mat.EigenSym
+	)
+	if !eigen.Factorize(adjacency, false) {
+		return Spectrum{}, ErrNoConvergence
+	}
+	res.Adjacency = eigen.Values(nil)
+
+	normalized := spectral.NewSymNormLaplacian(undirected)
+	if !eigen.Factorize(normalized.Matrix.(mat.Symmetric), false) {
+		return Spectrum{}, ErrNoConvergence
+	}
+	res.NormalizedLaplacian = eigen.Values(nil)
+
+	if !eigen.Factorize(laplacian.Matrix.(mat.Symmetric), true) {
+		return Spectrum{}, ErrNoConvergence
+	}
+	res.Laplacian = eigen.Values(nil)
+	if n < 2 {
+		return res, nil
+	}
+	res.AlgebraicConnectivity = res.Laplacian[1]
+
+	var vectors mat.Dense
+	eigen.VectorsTo(&vectors)
+	ids := make([]uint64, n)
+	for i, node := range laplacian.Nodes {
+		ids[i] = uint64(node.ID())
+	}
+	rows := make([]int, n)
+	for i := range rows {
+		rows[i] = i
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return ids[rows[i]] < ids[rows[j]]
+	})
+	sign := 1.0
+	for _, row := range rows {
+		if v := vectors.At(row, 1); v != 0 {
+			if v < 0 {
+				sign = -1
+			}
+			break
+		}
+	}
+	res.FiedlerVector = make(map[uint64]float64, n)
+	for i, id := range ids {
+		res.FiedlerVector[id] = sign * vectors.At(i, 1)
+	}
+	return res, nil
+}
@@ -0,0 +1,32 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraph_Spectrum(t *testing.T) {
+	// Path 1-2-3 with a self loop that the spectrum ignores.
+	graph := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 3})
+
+	got, err := Graph{}.Spectrum(graph)
+	require.NoError(t, err)
+
+	assertValues := func(want, got []float64) {
+		require.Len(t, got, len(want))
+		for i := range want {
+			assert.InDelta(t, want[i], got[i], 1e-9)
+		}
+	}
+	assertValues([]float64{-1.4142135623730951, 0, 1.4142135623730951}, got.Adjacency)
+	assertValues([]float64{0, 1, 3}, got.Laplacian)
+	assertValues([]float64{0, 1, 2}, got.NormalizedLaplacian)
+	assert.InDelta(t, 1, got.AlgebraicConnectivity, 1e-9)
+
+	require.Len(t, got.FiedlerVector, 3)
+	assert.InDelta(t, 0.7071067811865476, got.FiedlerVector[1], 1e-9)
+	assert.InDelta(t, 0, got.FiedlerVector[2], 1e-9)
+	assert.InDelta(t, -0.7071067811865476, got.FiedlerVector[3], 1e-9)
+}
@@ -0,0 +1,224 @@
+package graph
+
+import (
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+type Stats struct {
+	Nodes             uint64      `json:"nodes"`
+	Edges             uint64      `json:"edges"`
+	DirectedEdges     uint64      `json:"directedEdges"`
+	UndirectedEdges   uint64      `json:"undirectedEdges"`
+	Density           float64     `json:"density"`
+	Degree            DegreeStats `json:"degree"`
+	SelfLoops         uint64      `json:"selfLoops"`
+	ParallelEdges     uint64      `json:"parallelEdges"`
+	Components        uint64      `json:"components"`
+	AverageClustering float64     `json:"averageClustering"`
+	GlobalClustering  float64     `json:"globalClustering"`
+	Girth             uint64      `json:"girth"`
+	IsRegular         bool        `json:"isRegular"`
+	IsComplete        bool        `json:"isComplete"`
+	IsBipartite       bool        `json:"isBipartite"`
+	IsTree            bool        `json:"isTree"`
+}
+
+// DegreeStats describes degrees of nodes, where Histogram[d] is the number
+// of nodes of degree d.
+type DegreeStats struct {
+	Min       uint64   `json:"min"`
+	Max       uint64   `json:"max"`
+	Mean      float64  `json:"mean"`
+	Histogram []uint64 `json:"histogram"`
+}
+
+// Stats summarises structure of the graph. Degrees, components, clustering,
+// girth and the shape checks ignore edge directions; a self loop adds 2 to
+// the degree of its node.
+func (g Graph) Stats(graph model.Graph) Stats {
+	adj := newUndirectedAdjacency(graph)
+	n := uint64(len(adj.nodes))
+	stats := Stats{
+		Nodes: n,
+		Edges: uint64(len(graph.Edges)),
+	}
+
+	type pair struct {
+		from, to   uint64
+		isDirected bool
+	}
+	seen := make(map[pair]struct{})
+	for _, e := range graph.Edges {
+		if e.IsDirected {
+			stats.DirectedEdges++
+		} else {
+			stats.UndirectedEdges++
+		}
+		if e.From.ID == e.To.ID {
+			stats.SelfLoops++
+		}
+		p := pair{from: e.From.ID, to: e.To.ID, isDirected: e.IsDirected}
+		if !e.IsDirected && p.from > p.to {
+			p.from, p.to = p.to, p.from
+		}
+		if _, ok := seen[p]; ok {
+			stats.ParallelEdges++
+		}
+		seen[p] = struct{}{}
+	}
+	if n > 1 {
+		arcs := float64(stats.DirectedEdges + 2*stats.UndirectedEdges)
+		stats.Density = arcs / float64(n*(n-1))
+	}
+
+	degrees := make([]uint64, n)
+	for _, e := range graph.Edges {
+		degrees[adj.index[e.From.ID]]++
+		degrees[adj.index[e.To.ID]]++
+	}
+	stats.Degree = degreeStats(degrees)
+
+	_, components := adj.components()
+	stats.Components = uint64(components)
+	stats.AverageClustering, stats.GlobalClustering = clustering(adj)
+	stats.Girth = girth(adj)
+
+	stats.IsRegular = n > 0 && stats.Degree.Min == stats.Degree.Max
+	stats.IsComplete = n > 0
+	for _, neighbours := range adj.neighbourSets() {
+		if uint64(len(neighbours)) != n-1 {
+			stats.IsComplete = false
+		}
+	}
+	stats.IsBipartite = isBipartite(adj)
+	stats.IsTree = n > 0 && components == 1 && stats.Edges == n-1
+	return stats
+}
+
+func degreeStats(degrees []uint64) DegreeStats {
+	if len(degrees) == 0 {
+		return DegreeStats{}
+	}
+	stats := DegreeStats{Min: degrees[0]}
+	var total uint64
+	for _, d := range degrees {
+		if d < stats.Min {
+			stats.Min = d
+		}
+		if d > stats.Max {
+			stats.Max = d
+		}
+		total += d
+	}
+	stats.Mean = float64(total) / float64(len(degrees))
+	stats.Histogram = make([]uint64, stats.Max+1)
+	for _, d := range degrees {
+		stats.Histogram[d]++
+	}
+	return stats
+}
+
+// clustering returns the mean of local clustering coefficients and the
+// global one, the ratio of closed triplets to all connected triplets.
+func clustering(adj adjacency) (average, global float64) {
+	sets := adj.neighbourSets()
+	var triangles, triplets float64
+	for v := range sets {
+		d := float64(len(sets[v]))
+		if d < 2 {
+			continue
+		}
+		var links float64
+		for u := range sets[v] {
+			for w := range sets[v] {
+				if u < w {
+					if _, ok := sets[u][w]; ok {
+						links++
+					}
+				}
+			}
+		}
+		pairs := d * (d - 1) / 2
+		average += links / pairs
+		triangles += links
+		triplets += pairs
+	}
+	if len(sets) > 0 {
+		average /= float64(len(sets))
+	}
+	if triplets > 0 {
+		global = triangles / triplets
+	}
+	return average, global
+}
+
+// girth returns the length of the shortest cycle of an undirected view, or
+// 0 when there is none. Self loops are cycles of length 1 and parallel
+// edges cycles of length 2.
+func girth(adj adjacency) uint64 {
+	var best uint64
+	dist := make([]int, len(adj.nodes))
+	parentEdge := make([]int, len(adj.nodes))
+	for s := range adj.nodes {
+		for i := range dist {
+			dist[i] = -1
+		}
+		dist[s], parentEdge[s] = 0, -1
+		queue := []int{s}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			if best != 0 && uint64(2*dist[v]) >= best {
+				break
+			}
+			for _, w := range adj.out[v] {
+				if w.edge == parentEdge[v] {
+					continue
+				}
+				if dist[w.to] == -1 {
+					dist[w.to], parentEdge[w.to] = dist[v]+1, w.edge
+					queue = append(queue, w.to)
+					continue
+				}
+				if length := uint64(dist[v] + dist[w.to] + 1); best == 0 || length < best {
+					best = length
+				}
+			}
+		}
+	}
+	return best
+}
+
+func isBipartite(adj adjacency) bool {
+	_, ok := twoColoring(adj)
+	return ok
+}
+
+// twoColoring splits nodes into sides 0 and 1 so that no edge stays inside
+// one side, if that is possible.
+func twoColoring(adj adjacency) ([]int, bool) {
+	side := make([]int, len(adj.nodes))
+	for i := range side {
+		side[i] = -1
+	}
+	for s := range adj.nodes {
+		if side[s] != -1 {
+			continue
+		}
+		side[s] = 0
+		queue := []int{s}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, w := range adj.out[v] {
+				if side[w.to] == -1 {
+					side[w.to] = 1 - side[v]
+					queue = append(queue, w.to)
+				} else if side[w.to] == side[v] {
+					return nil, false
+				}
+			}
+		}
+	}
+	return side, true
+}
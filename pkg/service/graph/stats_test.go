@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_Stats(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph model.Graph
+		want  Stats
+	}{
+		{
+			name:  "triangle",
+			graph: edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1}),
+			want: Stats{
+				Nodes:             3,
+				Edges:             3,
+				UndirectedEdges:   3,
+				Density:           1,
+				Degree:            DegreeStats{Min: 2, Max: 2, Mean: 2, Histogram: []uint64{0, 0, 3}},
+				Components:        1,
+				AverageClustering: 1,
+				GlobalClustering:  1,
+				Girth:             3,
+				IsRegular:         true,
+				IsComplete:        true,
+			},
+		},
+		{
+			name:  "directed path with a parallel edge and a loop",
+			graph: edgesGraph(true, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{2, 3}, [2]uint64{4, 4}),
+			want: Stats{
+				Nodes:         4,
+				Edges:         4,
+				DirectedEdges: 4,
+				Density:       4.0 / 12,
+				Degree:        DegreeStats{Min: 1, Max: 3, Mean: 2, Histogram: []uint64{0, 1, 2, 1}},
+				SelfLoops:     1,
+				ParallelEdges: 1,
+				Components:    2,
+				Girth:         1,
+			},
+		},
+		{
+			name:  "tree",
+			graph: edgesGraph(false, [2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{3, 4}),
+			want: Stats{
+				Nodes:           4,
+				Edges:           3,
+				UndirectedEdges: 3,
+				Density:         0.5,
+				Degree:          DegreeStats{Min: 1, Max: 2, Mean: 1.5, Histogram: []uint64{0, 2, 2}},
+				Components:      1,
+				IsBipartite:     true,
+				IsTree:          true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Graph{}.Stats(tt.graph)
+			assert.InDelta(t, tt.want.Density, got.Density, 1e-9)
+			got.Density = tt.want.Density
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
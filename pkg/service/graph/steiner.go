@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+var ErrNotConnected = errors.New("nodes are not connected")
+
+type SteinerTree struct {
+	Edges        []model.Edge `json:"edges"`
+	Weight       float64      `json:"weight"`
+	SteinerNodes []uint64     `json:"steinerNodes"`
+}
+
+// SteinerTree connects the terminals with the algorithm of Kou, Markowsky
+// and Berman, which is at most twice as heavy as the optimal tree: terminals
+// are joined by a minimum spanning tree of their shortest path distances,
+// its edges are expanded into paths, and the result is reduced to a
+// spanning tree without leaves other than terminals. Edges are treated as
+// undirected. Steiner nodes are the non terminals the tree passes through.
+func (g Graph) SteinerTree(graph model.Graph, terminals []uint64) (SteinerTree, error) {
+	if err := checkWeights(graph); err != nil {
+		return SteinerTree{}, err
+	}
+	adj := newUndirectedAdjacency(graph)
+	isTerminal := make([]bool, len(adj.nodes))
+	var ts []int
+	for _, id := range terminals {
+		v, ok := adj.index[id]
+		if !ok {
+			return SteinerTree{}, ErrNodeNotFound
+		}
+		if !isTerminal[v] {
+			isTerminal[v] = true
+			ts = append(ts, v)
+		}
+	}
+	if len(ts) < 2 {
+		return SteinerTree{}, nil
+	}
+
+	type tree struct {
+		parent, parentEdge []int
+		dist               []float64
+	}
+	trees := make([]tree, len(ts))
+	for i, v := range ts {
+		p, pe, dist := adj.pathTree(v)
+		trees[i] = tree{parent: p, parentEdge: pe, dist: dist}
+	}
+
+	// Prim's algorithm on the distances between terminals, expanding every
+	// chosen link into its shortest path.
+	used := make(map[int]struct{})
+	joined := make([]bool, len(ts))
+	best := make([]float64, len(ts))
+	link := make([]int, len(ts))
+	joined[0] = true
+	for i := range ts {
+		best[i], link[i] = trees[0].dist[ts[i]], 0
+	}
+	for k := 1; k < len(ts); k++ {
+		next := -1
+		for i := range ts {
+			if !joined[i] && (next == -1 || best[i] < best[next]) {
+				next = i
+			}
+		}
+		if math.IsInf(best[next], 1) {
+			return SteinerTree{}, ErrNotConnected
+		}
+		joined[next] = true
+		t := trees[link[next]]
+		for v := ts[next]; v != ts[link[next]]; v = t.parent[v] {
+			used[t.parentEdge[v]] = struct{}{}
+		}
+		for i := range ts {
+			if !joined[i] && trees[next].dist[ts[i]] < best[i] {
+				best[i], link[i] = trees[next].dist[ts[i]], next
+			}
+		}
+	}
+
+	// Kruskal's algorithm on the union of the paths.
+	candidates := make([]int, 0, len(used))
+	for e := range used {
+		candidates = append(candidates, e)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		wi := edgeWeight(graph.Edges[candidates[i]], adj.weighted)
+		wj := edgeWeight(graph.Edges[candidates[j]], adj.weighted)
+		return wi < wj || wi == wj && candidates[i] < candidates[j]
+	})
+	root := make([]int, len(adj.nodes))
+	for i := range root {
+		root[i] = i
+	}
+	var find func(v int) int
+	find = func(v int) int {
+		if root[v] != v {
+			root[v] = find(root[v])
+		}
+		return root[v]
+	}
+	inTree := make(map[int]struct{})
+	degree := make([]int, len(adj.nodes))
+	for _, e := range candidates {
+		x, y := adj.index[graph.Edges[e].From.ID], adj.index[graph.Edges[e].To.ID]
+		if rx, ry := find(x), find(y); rx != ry {
+			root[rx] = ry
+			inTree[e] = struct{}{}
+			degree[x]++
+			degree[y]++
+		}
+	}
+
+	// Leaves that aren't terminals only add weight.
+	for pruned := true; pruned; {
+		pruned = false
+		for e := range inTree {
+			x, y := adj.index[graph.Edges[e].From.ID], adj.index[graph.Edges[e].To.ID]
+			if degree[x] == 1 && !isTerminal[x] || degree[y] == 1 && !isTerminal[y] {
+				delete(inTree, e)
+				degree[x]--
+				degree[y]--
+				pruned = true
+			}
+		}
+	}
+
+	res := SteinerTree{}
+	for e := range inTree {
+		res.Edges = append(res.Edges, graph.Edges[e])
+		res.Weight += edgeWeight(graph.Edges[e], adj.weighted)
+	}
+	sort.Slice(res.Edges, func(i, j int) bool {
+		return res.Edges[i].ID < res.Edges[j].ID
+	})
+	for v := range adj.nodes {
+		if degree[v] > 0 && !isTerminal[v] {
+			res.SteinerNodes = append(res.SteinerNodes, adj.nodes[v].ID)
+		}
+	}
+	return res, nil
+}
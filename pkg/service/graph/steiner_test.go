@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraph_SteinerTree(t *testing.T) {
+	// Terminals 1, 2 and 3 are pairwise joined by edges of weight 3 and
+	// reach the hub 4 by edges of weight 1; 5 hangs off the hub.
+	graph := edgesGraph(false,
+		[2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1},
+		[2]uint64{1, 4}, [2]uint64{2, 4}, [2]uint64{3, 4}, [2]uint64{4, 5})
+	for i, w := range []float64{3, 3, 3, 1, 1, 1, 1} {
+		graph.Edges[i].Weight = w
+	}
+
+	tests := []struct {
+		name      string
+		terminals []uint64
+		edges     [][2]uint64
+		weight    float64
+		steiner   []uint64
+	}{
+		{
+			name:      "through the hub",
+			terminals: []uint64{1, 2, 3},
+			edges:     [][2]uint64{{1, 4}, {2, 4}, {3, 4}},
+			weight:    3,
+			steiner:   []uint64{4},
+		},
+		{
+			name:      "two terminals",
+			terminals: []uint64{1, 5},
+			edges:     [][2]uint64{{1, 4}, {4, 5}},
+			weight:    2,
+			steiner:   []uint64{4},
+		},
+		{
+			name:      "single terminal",
+			terminals: []uint64{2, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Graph{}.SteinerTree(graph, tt.terminals)
+			require.NoError(t, err)
+			var edges [][2]uint64
+			for _, e := range got.Edges {
+				edges = append(edges, [2]uint64{e.From.ID, e.To.ID})
+			}
+			assert.Equal(t, tt.edges, edges)
+			assert.Equal(t, tt.weight, got.Weight)
+			assert.Equal(t, tt.steiner, got.SteinerNodes)
+		})
+	}
+}
+
+func TestGraph_SteinerTreePrunesLeaves(t *testing.T) {
+	// A cycle 1-2-3-4-5-6 where the terminals sit on one side.
+	graph := edgesGraph(false,
+		[2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 4}, [2]uint64{4, 5}, [2]uint64{5, 6}, [2]uint64{6, 1})
+
+	got, err := Graph{}.SteinerTree(graph, []uint64{6, 3, 1})
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, got.Weight)
+	assert.Equal(t, []uint64{2}, got.SteinerNodes)
+}
+
+func TestGraph_SteinerTreeErrors(t *testing.T) {
+	graph := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{3, 4})
+
+	_, err := Graph{}.SteinerTree(graph, []uint64{1, 7})
+	assert.Equal(t, ErrNodeNotFound, err)
+	_, err = Graph{}.SteinerTree(graph, []uint64{1, 3})
+	assert.Equal(t, ErrNotConnected, err)
+
+	graph.Edges[0].Weight = -1
+	_, err = Graph{}.SteinerTree(graph, []uint64{1, 2})
+	assert.Equal(t, ErrNegativeWeight, err)
+}
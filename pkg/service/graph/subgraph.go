@@ -0,0 +1,62 @@
+package graph
+
+import (
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+// InducedSubgraph keeps the given nodes and every edge between them.
+// Nodes and edges keep their IDs and attributes.
+func (g Graph) InducedSubgraph(graph model.Graph, nodeIDs []uint64) model.Graph {
+	keep := make(map[uint64]struct{}, len(nodeIDs))
+	for _, id := range nodeIDs {
+		keep[id] = struct{}{}
+	}
+	return inducedSubgraph(graph, keep)
+}
+
+// Ego returns the subgraph induced by nodes at most radius edges away from
+// the given node, ignoring edge directions.
+func (g Graph) Ego(graph model.Graph, node, radius uint64) (model.Graph, error) {
+	adj := newUndirectedAdjacency(graph)
+	start, ok := adj.index[node]
+	if !ok {
+		return model.Graph{}, ErrNodeNotFound
+	}
+	dist := map[int]uint64{start: 0}
+	keep := map[uint64]struct{}{node: {}}
+	queue := []int{start}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if dist[v] == radius {
+			continue
+		}
+		for _, w := range adj.out[v] {
+			if _, ok := dist[w.to]; !ok {
+				dist[w.to] = dist[v] + 1
+				keep[adj.nodes[w.to].ID] = struct{}{}
+				queue = append(queue, w.to)
+			}
+		}
+	}
+	return inducedSubgraph(graph, keep), nil
+}
+
+func inducedSubgraph(graph model.Graph, keep map[uint64]struct{}) model.Graph {
+	res := graph
+	res.Nodes = nil
+	res.Edges = nil
+	for _, n := range graphNodes(graph) {
+		if _, ok := keep[n.ID]; ok {
+			res.Nodes = append(res.Nodes, n)
+		}
+	}
+	for _, e := range graph.Edges {
+		_, from := keep[e.From.ID]
+		_, to := keep[e.To.ID]
+		if from && to {
+			res.Edges = append(res.Edges, e)
+		}
+	}
+	return res
+}
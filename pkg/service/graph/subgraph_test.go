@@ -0,0 +1,62 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+func TestGraph_InducedSubgraph(t *testing.T) {
+	graph := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1}, [2]uint64{3, 4})
+	graph.ID = 7
+	graph.Nodes = []model.Node{{ID: 1, X: 10}, {ID: 2, X: 20}, {ID: 3, X: 30}, {ID: 4, X: 40}, {ID: 5, X: 50}}
+
+	got := Graph{}.InducedSubgraph(graph, []uint64{1, 3, 5})
+	assert.Equal(t, uint64(7), got.ID)
+	assert.Equal(t, []model.Node{{ID: 1, X: 10}, {ID: 3, X: 30}, {ID: 5, X: 50}}, got.Nodes)
+	assert.Equal(t, []model.Edge{graph.Edges[2]}, got.Edges)
+}
+
+func TestGraph_Ego(t *testing.T) {
+	graph := edgesGraph(true, [2]uint64{1, 2}, [2]uint64{3, 2}, [2]uint64{3, 4}, [2]uint64{4, 5})
+
+	tests := []struct {
+		name   string
+		radius uint64
+		want   [][2]uint64
+	}{
+		{
+			name:   "node only",
+			radius: 0,
+			want:   nil,
+		},
+		{
+			name:   "neighbours",
+			radius: 1,
+			want:   [][2]uint64{{1, 2}, {3, 2}},
+		},
+		{
+			name:   "two hops",
+			radius: 2,
+			want:   [][2]uint64{{1, 2}, {3, 2}, {3, 4}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Graph{}.Ego(graph, 2, tt.radius)
+			require.NoError(t, err)
+			if tt.want == nil {
+				assert.Empty(t, got.Edges)
+				assert.Len(t, got.Nodes, 1)
+				return
+			}
+			assert.Equal(t, tt.want, edgePairs(got))
+		})
+	}
+
+	_, err := Graph{}.Ego(graph, 42, 1)
+	assert.Equal(t, ErrNodeNotFound, err)
+}
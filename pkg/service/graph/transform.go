@@ -0,0 +1,180 @@
+package graph
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+type TransformKind string
+
+const (
+	ComplementTransform  TransformKind = "complement"
+	LineGraphTransform   TransformKind = "lineGraph"
+	TransposeTransform   TransformKind = "transpose"
+	UnderlyingTransform  TransformKind = "underlying"
+	PowerTransform       TransformKind = "power"
+	SubdivisionTransform TransformKind = "subdivision"
+)
+
+// Transform returns a new graph with fresh IDs built from the given one.
+// The power transform connects nodes at distance at most k, where k
+// defaults to 2. Graphs with at least one directed edge get directed
+// complements, line graphs and powers.
+func (g Graph) Transform(graph model.Graph, kind TransformKind, k uint64) (model.Graph, error) {
+	b := newBuilder(strings.TrimSpace(graph.Name + " " + string(kind)))
+	switch kind {
+	case ComplementTransform:
+		complement(b, graph)
+	case LineGraphTransform:
+		lineGraph(b, graph)
+	case TransposeTransform:
+		transpose(b, graph)
+	case UnderlyingTransform:
+		underlying(b, graph)
+	case PowerTransform:
+		if k == 0 {
+			k = 2
+		}
+		power(b, graph, k)
+	case SubdivisionTransform:
+		subdivision(b, graph)
+	default:
+		return model.Graph{}, ErrUnknownKind
+	}
+	return b.result(), nil
+}
+
+// copyNodes adds every node of the graph to the builder, returning the new
+// nodes in the order of graphNodes.
+func copyNodes(b *builder, graph model.Graph) []model.Node {
+	nodes := graphNodes(graph)
+	res := make([]model.Node, len(nodes))
+	for i, n := range nodes {
+		res[i] = b.addNode(n)
+	}
+	return res
+}
+
+func complement(b *builder, graph model.Graph) {
+	adj := newAdjacency(graph)
+	isDirected := hasDirectedEdges(graph)
+	nodes := copyNodes(b, graph)
+	sets := adj.neighbourSets()
+	for v := range nodes {
+		for u := range nodes {
+			if u == v || !isDirected && u < v {
+				continue
+			}
+			if _, ok := sets[v][u]; !ok {
+				b.connect(nodes[v], nodes[u], isDirected)
+			}
+		}
+	}
+}
+
+// lineGraph has a node for every edge, placed at the edge midpoint. Two of
+// them are connected when an arc of the first edge ends where an arc of the
+// second one starts.
+func lineGraph(b *builder, graph model.Graph) {
+	adj := newAdjacency(graph)
+	isDirected := hasDirectedEdges(graph)
+	nodes := make([]model.Node, len(graph.Edges))
+	for i, e := range graph.Edges {
+		from, to := adj.nodes[adj.index[e.From.ID]], adj.nodes[adj.index[e.To.ID]]
+		nodes[i] = b.addNode(model.Node{
+			X:     midpoint(from.X, to.X),
+			Y:     midpoint(from.Y, to.Y),
+			Name:  e.Name,
+			Color: e.Color,
+		})
+	}
+	type pair struct{ from, to int }
+	added := make(map[pair]struct{})
+	for v := range adj.nodes {
+		for _, in := range adj.in[v] {
+			for _, out := range adj.out[v] {
+				p := pair{from: in.edge, to: out.edge}
+				if !isDirected && p.from > p.to {
+					p.from, p.to = p.to, p.from
+				}
+				if _, ok := added[p]; ok || p.from == p.to {
+					continue
+				}
+				added[p] = struct{}{}
+				b.connect(nodes[p.from], nodes[p.to], isDirected)
+			}
+		}
+	}
+}
+
+func transpose(b *builder, graph model.Graph) {
+	nodes := copyNodes(b, graph)
+	index := newAdjacency(graph).index
+	for _, e := range graph.Edges {
+		from, to := nodes[index[e.From.ID]], nodes[index[e.To.ID]]
+		if e.IsDirected {
+			from, to = to, from
+		}
+		b.addEdge(e, from, to)
+	}
+}
+
+// underlying drops edge directions, parallel edges and self loops.
+func underlying(b *builder, graph model.Graph) {
+	adj := newUndirectedAdjacency(graph)
+	nodes := copyNodes(b, graph)
+	for v, set := range adj.neighbourSets() {
+		for _, u := range sortedKeys(set) {
+			if v < u {
+				b.connect(nodes[v], nodes[u], false)
+			}
+		}
+	}
+}
+
+func power(b *builder, graph model.Graph, k uint64) {
+	adj := newAdjacency(graph).unweighted()
+	isDirected := hasDirectedEdges(graph)
+	nodes := copyNodes(b, graph)
+	for v := range nodes {
+		dist := adj.shortestPaths(v).dist
+		for u := range nodes {
+			if u == v || !isDirected && u < v || math.IsInf(dist[u], 1) || dist[u] > float64(k) {
+				continue
+			}
+			b.connect(nodes[v], nodes[u], isDirected)
+		}
+	}
+}
+
+// subdivision replaces every edge with a path through a new node placed at
+// the edge midpoint.
+func subdivision(b *builder, graph model.Graph) {
+	nodes := copyNodes(b, graph)
+	index := newAdjacency(graph).index
+	for _, e := range graph.Edges {
+		from, to := nodes[index[e.From.ID]], nodes[index[e.To.ID]]
+		middle := b.addNode(model.Node{X: midpoint(from.X, to.X), Y: midpoint(from.Y, to.Y)})
+		b.addEdge(e, from, middle)
+		b.addEdge(e, middle, to)
+	}
+}
+
+func midpoint(a, b uint64) uint64 {
+	if a > b {
+		a, b = b, a
+	}
+	return a + (b-a)/2
+}
+
+func sortedKeys(set map[int]struct{}) []int {
+	keys := make([]int, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
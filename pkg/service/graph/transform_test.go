@@ -0,0 +1,108 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+// edgePairs returns endpoint IDs of every edge of the graph.
+func edgePairs(graph model.Graph) [][2]uint64 {
+	pairs := make([][2]uint64, 0, len(graph.Edges))
+	for _, e := range graph.Edges {
+		pairs = append(pairs, [2]uint64{e.From.ID, e.To.ID})
+	}
+	return pairs
+}
+
+func TestGraph_Transform(t *testing.T) {
+	path := edgesGraph(false, [2]uint64{10, 20}, [2]uint64{20, 30}, [2]uint64{30, 40})
+	star := edgesGraph(false, [2]uint64{10, 20}, [2]uint64{10, 30}, [2]uint64{10, 40})
+	directed := edgesGraph(true, [2]uint64{10, 20}, [2]uint64{20, 10}, [2]uint64{20, 30}, [2]uint64{30, 30})
+
+	type args struct {
+		graph model.Graph
+		kind  TransformKind
+		k     uint64
+	}
+	tests := []struct {
+		name  string
+		args  args
+		nodes int
+		want  [][2]uint64
+	}{
+		{
+			name:  "complement",
+			args:  args{graph: path, kind: ComplementTransform},
+			nodes: 4,
+			want:  [][2]uint64{{1, 3}, {1, 4}, {2, 4}},
+		},
+		{
+			name:  "line graph of a star",
+			args:  args{graph: star, kind: LineGraphTransform},
+			nodes: 3,
+			want:  [][2]uint64{{1, 2}, {1, 3}, {2, 3}},
+		},
+		{
+			name:  "directed line graph",
+			args:  args{graph: edgesGraph(true, [2]uint64{10, 20}, [2]uint64{20, 30}), kind: LineGraphTransform},
+			nodes: 2,
+			want:  [][2]uint64{{1, 2}},
+		},
+		{
+			name:  "transpose",
+			args:  args{graph: directed, kind: TransposeTransform},
+			nodes: 3,
+			want:  [][2]uint64{{2, 1}, {1, 2}, {3, 2}, {3, 3}},
+		},
+		{
+			name:  "underlying",
+			args:  args{graph: directed, kind: UnderlyingTransform},
+			nodes: 3,
+			want:  [][2]uint64{{1, 2}, {2, 3}},
+		},
+		{
+			name:  "square",
+			args:  args{graph: path, kind: PowerTransform},
+			nodes: 4,
+			want:  [][2]uint64{{1, 2}, {1, 3}, {2, 3}, {2, 4}, {3, 4}},
+		},
+		{
+			name:  "cube",
+			args:  args{graph: path, kind: PowerTransform, k: 3},
+			nodes: 4,
+			want:  [][2]uint64{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}},
+		},
+		{
+			name:  "subdivision",
+			args:  args{graph: edgesGraph(false, [2]uint64{10, 20}), kind: SubdivisionTransform},
+			nodes: 3,
+			want:  [][2]uint64{{1, 3}, {3, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Graph{}.Transform(tt.args.graph, tt.args.kind, tt.args.k)
+			require.NoError(t, err)
+			assert.Len(t, got.Nodes, tt.nodes)
+			assert.Equal(t, tt.want, edgePairs(got))
+		})
+	}
+}
+
+func TestGraph_TransformKeepsAttributes(t *testing.T) {
+	graph := edgesGraph(false, [2]uint64{10, 20})
+	graph.Nodes = []model.Node{{ID: 10, X: 100, Y: 40, Name: "a"}, {ID: 20, X: 300, Y: 80, Name: "b"}}
+
+	got, err := Graph{}.Transform(graph, SubdivisionTransform, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []model.Node{
+		{ID: 1, X: 100, Y: 40, Name: "a"},
+		{ID: 2, X: 300, Y: 80, Name: "b"},
+		{ID: 3, X: 200, Y: 60},
+	}, got.Nodes)
+	assert.Equal(t, got.Nodes[2], got.Edges[0].To)
+}
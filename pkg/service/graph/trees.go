@@ -0,0 +1,381 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+var (
+	ErrNotTree       = errors.New("graph is not a tree")
+	ErrInvalidPrufer = errors.New("invalid Prüfer code")
+)
+
+// RootedTree describes a tree hanging from its root. The root has no
+// parent and depth 0.
+type RootedTree struct {
+	Root   uint64            `json:"root"`
+	Parent map[uint64]uint64 `json:"parent"`
+	Depth  map[uint64]uint64 `json:"depth"`
+}
+
+// treeAdjacency returns the undirected view of the graph if it's a tree:
+// connected, with one edge less than nodes, so without loops and parallel
+// edges either.
+func treeAdjacency(graph model.Graph) (adjacency, error) {
+	adj := newUndirectedAdjacency(graph)
+	if len(adj.nodes) == 0 || len(graph.Edges) != len(adj.nodes)-1 {
+		return adjacency{}, ErrNotTree
+	}
+	if _, components := adj.components(); components != 1 {
+		return adjacency{}, ErrNotTree
+	}
+	return adj, nil
+}
+
+// rootedTree is a tree rooted at root, where order lists nodes so that
+// every node comes after its parent.
+type rootedTree struct {
+	adj    adjacency
+	root   int
+	parent []int
+	depth  []int
+	order  []int
+}
+
+func newRootedTree(adj adjacency, root int) rootedTree {
+	t := rootedTree{
+		adj:    adj,
+		root:   root,
+		parent: make([]int, len(adj.nodes)),
+		depth:  make([]int, len(adj.nodes)),
+		order:  []int{root},
+	}
+	t.parent[root] = -1
+	for i := 0; i < len(t.order); i++ {
+		v := t.order[i]
+		for _, w := range adj.out[v] {
+			if w.to != t.parent[v] {
+				t.parent[w.to] = v
+				t.depth[w.to] = t.depth[v] + 1
+				t.order = append(t.order, w.to)
+			}
+		}
+	}
+	return t
+}
+
+func rootTree(graph model.Graph, root uint64) (rootedTree, error) {
+	adj, err := treeAdjacency(graph)
+	if err != nil {
+		return rootedTree{}, err
+	}
+	r, ok := adj.index[root]
+	if !ok {
+		return rootedTree{}, ErrNodeNotFound
+	}
+	return newRootedTree(adj, r), nil
+}
+
+// children lists children of every node in order of their IDs.
+func (t rootedTree) children() [][]int {
+	children := make([][]int, len(t.parent))
+	for v, p := range t.parent {
+		if p != -1 {
+			children[p] = append(children[p], v)
+		}
+	}
+	return children
+}
+
+// RootTree returns parents and depths of nodes of a tree rooted at root.
+func (g Graph) RootTree(graph model.Graph, root uint64) (RootedTree, error) {
+	t, err := rootTree(graph, root)
+	if err != nil {
+		return RootedTree{}, err
+	}
+	res := RootedTree{
+		Root:   root,
+		Parent: make(map[uint64]uint64, len(t.parent)-1),
+		Depth:  make(map[uint64]uint64, len(t.parent)),
+	}
+	for v, p := range t.parent {
+		if p != -1 {
+			res.Parent[t.adj.nodes[v].ID] = t.adj.nodes[p].ID
+		}
+		res.Depth[t.adj.nodes[v].ID] = uint64(t.depth[v])
+	}
+	return res, nil
+}
+
+// LowestCommonAncestor returns the deepest node having all the given nodes
+// in its subtree when the tree is rooted at root. Ancestors are found by
+// binary lifting.
+func (g Graph) LowestCommonAncestor(graph model.Graph, root uint64, nodeIDs []uint64) (uint64, error) {
+	t, err := rootTree(graph, root)
+	if err != nil {
+		return 0, err
+	}
+	if len(nodeIDs) == 0 {
+		return root, nil
+	}
+	up := t.ancestors()
+	res, ok := t.adj.index[nodeIDs[0]]
+	if !ok {
+		return 0, ErrNodeNotFound
+	}
+	for _, id := range nodeIDs[1:] {
+		v, ok := t.adj.index[id]
+		if !ok {
+			return 0, ErrNodeNotFound
+		}
+		res = t.lca(up, res, v)
+	}
+	return t.adj.nodes[res].ID, nil
+}
+
+// ancestors returns the table of 2^k-th ancestors, where the root is its
+// own ancestor.
+func (t rootedTree) ancestors() [][]int {
+	levels := 1
+	for 1<<uint(levels) < len(t.parent) {
+		levels++
+	}
+	up := make([][]int, levels)
+	up[0] = make([]int, len(t.parent))
+	for v, p := range t.parent {
+		up[0][v] = p
+		if p == -1 {
+			up[0][v] = v
+		}
+	}
+	for k := 1; k < levels; k++ {
+		up[k] = make([]int, len(t.parent))
+		for v := range t.parent {
+			up[k][v] = up[k-1][up[k-1][v]]
+		}
+	}
+	return up
+}
+
+func (t rootedTree) lca(up [][]int, a, b int) int {
+	if t.depth[a] < t.depth[b] {
+		a, b = b, a
+	}
+	for k := len(up) - 1; k >= 0; k-- {
+		if t.depth[a]-1<<uint(k) >= t.depth[b] {
+			a = up[k][a]
+		}
+	}
+	if a == b {
+		return a
+	}
+	for k := len(up) - 1; k >= 0; k-- {
+		if up[k][a] != up[k][b] {
+			a, b = up[k][a], up[k][b]
+		}
+	}
+	return up[0][a]
+}
+
+// PruferCode encodes a tree by repeatedly removing its leaf of the smallest
+// ID and writing down the ID of its neighbour, until two nodes are left.
+func (g Graph) PruferCode(graph model.Graph) ([]uint64, error) {
+	adj, err := treeAdjacency(graph)
+	if err != nil {
+		return nil, err
+	}
+	n := len(adj.nodes)
+	if n <= 2 {
+		return []uint64{}, nil
+	}
+	// Rooted at the largest ID, which is never removed, every removed leaf
+	// is connected to its parent. Nodes are sorted by ID, so the smallest
+	// leaf is found by a pointer moving forward, unless removing a leaf
+	// made its parent a leaf of a smaller ID.
+	t := newRootedTree(adj, n-1)
+	degree := make([]int, n)
+	for v := range adj.nodes {
+		degree[v] = len(adj.out[v])
+	}
+	ptr := 0
+	for degree[ptr] != 1 {
+		ptr++
+	}
+	leaf := ptr
+	code := make([]uint64, 0, n-2)
+	for i := 0; i < n-2; i++ {
+		next := t.parent[leaf]
+		code = append(code, adj.nodes[next].ID)
+		degree[next]--
+		if degree[next] == 1 && next < ptr {
+			leaf = next
+			continue
+		}
+		ptr++
+		for degree[ptr] != 1 {
+			ptr++
+		}
+		leaf = ptr
+	}
+	return code, nil
+}
+
+// FromPrufer decodes a tree on nodes 1..len(code)+2 and lays it out in
+// layers below node 1.
+func (g Graph) FromPrufer(name string, code []uint64) (model.Graph, error) {
+	n := len(code) + 2
+	degree := make([]int, n)
+	for i := range degree {
+		degree[i] = 1
+	}
+	for _, id := range code {
+		if id < 1 || id > uint64(n) {
+			return model.Graph{}, ErrInvalidPrufer
+		}
+		degree[id-1]++
+	}
+
+	b := newBuilder(name)
+	nodes := b.addNumberedNodes(n)
+	ptr := 0
+	for degree[ptr] != 1 {
+		ptr++
+	}
+	leaf := ptr
+	for _, id := range code {
+		v := int(id - 1)
+		b.connect(nodes[leaf], nodes[v], false)
+		degree[v]--
+		if degree[v] == 1 && v < ptr {
+			leaf = v
+			continue
+		}
+		ptr++
+		for degree[ptr] != 1 {
+			ptr++
+		}
+		leaf = ptr
+	}
+	b.connect(nodes[leaf], nodes[n-1], false)
+
+	return treeLayout(b.result()), nil
+}
+
+// TreeIsomorphic reports whether two trees have the same shape, comparing
+// them rooted at their centers with the algorithm of Aho, Hopcroft and
+// Ullman. A tree has one or two centers and rooting at either gives a
+// canonical form, so the trees are isomorphic if any pair of forms agrees.
+func (g Graph) TreeIsomorphic(first, second model.Graph) (bool, error) {
+	a, err := treeAdjacency(first)
+	if err != nil {
+		return false, err
+	}
+	b, err := treeAdjacency(second)
+	if err != nil {
+		return false, err
+	}
+	if len(a.nodes) != len(b.nodes) {
+		return false, nil
+	}
+	labels := make(map[string]int)
+	for _, ca := range treeCenters(a) {
+		for _, cb := range treeCenters(b) {
+			if ahuLabel(newRootedTree(a, ca), labels) == ahuLabel(newRootedTree(b, cb), labels) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// ahuLabel names every subtree by the sorted names of its children's
+// subtrees, sharing names through labels, and returns the root's name.
+func ahuLabel(t rootedTree, labels map[string]int) int {
+	children := t.children()
+	name := make([]int, len(t.parent))
+	for i := len(t.order) - 1; i >= 0; i-- {
+		v := t.order[i]
+		names := make([]int, 0, len(children[v]))
+		for _, c := range children[v] {
+			names = append(names, name[c])
+		}
+		sort.Ints(names)
+		parts := make([]string, len(names))
+		for j, id := range names {
+			parts[j] = strconv.Itoa(id)
+		}
+		key := strings.Join(parts, ",")
+		id, ok := labels[key]
+		if !ok {
+			id = len(labels)
+			labels[key] = id
+		}
+		name[v] = id
+	}
+	return name[t.root]
+}
+
+// treeCenters peels leaves layer by layer until one or two nodes are left.
+func treeCenters(adj adjacency) []int {
+	n := len(adj.nodes)
+	degree := make([]int, n)
+	var leaves []int
+	for v := range adj.nodes {
+		degree[v] = len(adj.out[v])
+		if degree[v] <= 1 {
+			leaves = append(leaves, v)
+		}
+	}
+	left := n
+	for left > 2 {
+		left -= len(leaves)
+		var next []int
+		for _, v := range leaves {
+			for _, w := range adj.out[v] {
+				degree[w.to]--
+				if degree[w.to] == 1 {
+					next = append(next, w.to)
+				}
+			}
+		}
+		leaves = next
+	}
+	return leaves
+}
+
+// Centroid returns the one or two nodes whose removal leaves components of
+// at most half of the nodes.
+func (g Graph) Centroid(graph model.Graph) ([]uint64, error) {
+	adj, err := treeAdjacency(graph)
+	if err != nil {
+		return nil, err
+	}
+	n := len(adj.nodes)
+	t := newRootedTree(adj, 0)
+	size := make([]int, n)
+	largest := make([]int, n)
+	for i := n - 1; i >= 0; i-- {
+		v := t.order[i]
+		size[v]++
+		if p := t.parent[v]; p != -1 {
+			size[p] += size[v]
+			if size[v] > largest[p] {
+				largest[p] = size[v]
+			}
+		}
+	}
+	var res []uint64
+	for v := range adj.nodes {
+		if n-size[v] > largest[v] {
+			largest[v] = n - size[v]
+		}
+		if 2*largest[v] <= n {
+			res = append(res, adj.nodes[v].ID)
+		}
+	}
+	return res, nil
+}
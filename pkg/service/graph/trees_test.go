@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+// sampleTree is rooted at 1, which has children 2, 3 and 4. Node 2 has
+// children 5 and 6, and the path 4-7-8 hangs off 4.
+func sampleTree() model.Graph {
+	return edgesGraph(false,
+		[2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{1, 4}, [2]uint64{2, 5},
+		[2]uint64{6, 2}, [2]uint64{4, 7}, [2]uint64{7, 8})
+}
+
+func TestGraph_RootTree(t *testing.T) {
+	got, err := Graph{}.RootTree(sampleTree(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, RootedTree{
+		Root:   2,
+		Parent: map[uint64]uint64{1: 2, 3: 1, 4: 1, 5: 2, 6: 2, 7: 4, 8: 7},
+		Depth:  map[uint64]uint64{1: 1, 2: 0, 3: 2, 4: 2, 5: 1, 6: 1, 7: 3, 8: 4},
+	}, got)
+
+	_, err = Graph{}.RootTree(sampleTree(), 9)
+	assert.Equal(t, ErrNodeNotFound, err)
+	_, err = Graph{}.RootTree(edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 1}), 1)
+	assert.Equal(t, ErrNotTree, err)
+	_, err = Graph{}.RootTree(edgesGraph(false, [2]uint64{1, 2}, [2]uint64{3, 3}), 1)
+	assert.Equal(t, ErrNotTree, err)
+}
+
+func TestGraph_LowestCommonAncestor(t *testing.T) {
+	tests := []struct {
+		name  string
+		root  uint64
+		nodes []uint64
+		want  uint64
+	}{
+		{name: "siblings", root: 1, nodes: []uint64{5, 6}, want: 2},
+		{name: "different branches", root: 1, nodes: []uint64{5, 8}, want: 1},
+		{name: "ancestor", root: 1, nodes: []uint64{8, 4}, want: 4},
+		{name: "same node", root: 1, nodes: []uint64{7, 7}, want: 7},
+		{name: "several nodes", root: 1, nodes: []uint64{5, 6, 3}, want: 1},
+		{name: "other root", root: 8, nodes: []uint64{5, 3}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Graph{}.LowestCommonAncestor(sampleTree(), tt.root, tt.nodes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGraph_Prufer(t *testing.T) {
+	code, err := Graph{}.PruferCode(sampleTree())
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 2, 1, 4, 7}, code)
+
+	tree, err := Graph{}.FromPrufer("decoded", code)
+	require.NoError(t, err)
+	assert.Equal(t, "decoded", tree.Name)
+	assert.Len(t, tree.Nodes, 8)
+	assert.ElementsMatch(t, normalizedPairs(sampleTree()), normalizedPairs(tree))
+	for _, n := range tree.Nodes {
+		assert.NotZero(t, n.X)
+		assert.NotZero(t, n.Y)
+	}
+
+	tree, err = Graph{}.FromPrufer("", nil)
+	require.NoError(t, err)
+	assert.Equal(t, [][2]uint64{{1, 2}}, edgePairs(tree))
+
+	_, err = Graph{}.FromPrufer("", []uint64{1, 5})
+	assert.Equal(t, ErrInvalidPrufer, err)
+}
+
+func TestGraph_TreeIsomorphic(t *testing.T) {
+	// sampleTree relabelled.
+	relabelled := edgesGraph(false,
+		[2]uint64{10, 20}, [2]uint64{10, 30}, [2]uint64{10, 40}, [2]uint64{40, 50},
+		[2]uint64{40, 60}, [2]uint64{20, 70}, [2]uint64{70, 80})
+	// Same degree sequence, but the path hangs off a leaf of 2.
+	different := edgesGraph(false,
+		[2]uint64{1, 2}, [2]uint64{1, 3}, [2]uint64{1, 4}, [2]uint64{2, 5},
+		[2]uint64{2, 6}, [2]uint64{5, 7}, [2]uint64{4, 8})
+	// Two centers: paths of even length.
+	path := edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 4})
+	reversedPath := edgesGraph(false, [2]uint64{4, 3}, [2]uint64{1, 3}, [2]uint64{2, 4})
+
+	tests := []struct {
+		name          string
+		first, second model.Graph
+		want          bool
+	}{
+		{name: "relabelled", first: sampleTree(), second: relabelled, want: true},
+		{name: "different", first: sampleTree(), second: different, want: false},
+		{name: "two centers", first: path, second: reversedPath, want: true},
+		{name: "sizes", first: path, second: sampleTree(), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Graph{}.TreeIsomorphic(tt.first, tt.second)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGraph_Centroid(t *testing.T) {
+	got, err := Graph{}.Centroid(sampleTree())
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1}, got)
+
+	got, err = Graph{}.Centroid(edgesGraph(false, [2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 4}))
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2, 3}, got)
+}
+
+// normalizedPairs returns endpoints of every edge with the smaller ID first.
+func normalizedPairs(graph model.Graph) [][2]uint64 {
+	pairs := edgePairs(graph)
+	for i, p := range pairs {
+		if p[0] > p[1] {
+			pairs[i] = [2]uint64{p[1], p[0]}
+		}
+	}
+	return pairs
+}
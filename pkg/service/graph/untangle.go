@@ -0,0 +1,144 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/illfate2/graph-api/pkg/model"
+)
+
+const (
+	// untangleRounds is the number of times every node may be moved.
+	untangleRounds = 50
+	// untangleCandidates is the number of random positions near a node tried
+	// on every move.
+	untangleCandidates = 8
+	// minNodeDistance keeps moved nodes from covering other nodes.
+	minNodeDistance = 10
+	// maxUntangleEdges limits graphs that are untangled, every move counts
+	// crossings of the moved edges with all others.
+	maxUntangleEdges = 150
+	// maxCrossingEdges limits graphs whose crossings are counted, every
+	// pair of edges is compared.
+	maxCrossingEdges = 5000
+	// maxListedCrossings limits crossings listed in a response, all of them
+	// are counted.
+	maxListedCrossings = 1000
+)
+
+var ErrTooManyEdges = errors.New("graph has too many edges")
+
+type Crossings struct {
+	Count     int            `json:"count"`
+	Crossings []EdgeCrossing `json:"crossings"`
+}
+
+// Crossings counts and lists pairs of edges crossing in the current drawing
+// of the graph, see Geometry.
+func (g Graph) Crossings(graph model.Graph) (Crossings, error) {
+	count, crossings, err := edgeCrossings(graph)
+	if err != nil {
+		return Crossings{}, err
+	}
+	return Crossings{
+		Count:     count,
+		Crossings: crossings,
+	}, nil
+}
+
+// Untangle returns a copy of the graph with nodes moved to reduce edge
+// crossings. In every round nodes on crossing edges are visited in random
+// order and moved to the best of the barycenter of their neighbours, random
+// positions nearby and a random position anywhere on the canvas, as long
+// as this removes crossings. The nearby area shrinks after every round.
+// Crossings are counted like in Crossings. Moved nodes stay on the canvas
+// and keep their distance from other nodes; the same seed gives the same
+// result. Graphs with more than maxUntangleEdges edges are rejected with
+// ErrTooManyEdges.
+func (g Graph) Untangle(graph model.Graph, seed int64) (model.Graph, error) {
+	if len(graph.Edges) > maxUntangleEdges {
+		return model.Graph{}, ErrTooManyEdges
+	}
+	adj := newUndirectedAdjacency(graph)
+	points := make([]point, len(adj.nodes))
+	for i, n := range adj.nodes {
+		points[i] = point{x: float64(n.X), y: float64(n.Y)}
+	}
+	edges := simpleEdges(adj)
+	incident := make([][]int, len(points))
+	for i, e := range edges {
+		incident[e[0]] = append(incident[e[0]], i)
+		incident[e[1]] = append(incident[e[1]], i)
+	}
+
+	// local counts crossings of edges at node v, all of them change when v
+	// moves and no other do.
+	local := func(v int) int {
+		return countCrossings(edges, points, v)
+	}
+	free := func(v int, p point) bool {
+		for u, q := range points {
+			if u != v && math.Hypot(p.x-q.x, p.y-q.y) < minNodeDistance {
+				return false
+			}
+		}
+		return true
+	}
+
+	box := canvas()
+	onCanvas := func(p point) point {
+		return point{
+			x: math.Round(math.Min(math.Max(p.x, box.minX), box.maxX)),
+			y: math.Round(math.Min(math.Max(p.y, box.minY), box.maxY)),
+		}
+	}
+	rng := rand.New(rand.NewSource(seed))
+	radius := (box.maxX - box.minX) / 2
+	for round := 0; round < untangleRounds && countCrossings(edges, points, -1) > 0; round++ {
+		for _, v := range rng.Perm(len(points)) {
+			cost := local(v)
+			if cost == 0 {
+				continue
+			}
+			var candidates []point
+			if len(incident[v]) > 0 {
+				var mean point
+				for _, i := range incident[v] {
+					u := edges[i][0] + edges[i][1] - v
+					mean.x += points[u].x
+					mean.y += points[u].y
+				}
+				mean.x /= float64(len(incident[v]))
+				mean.y /= float64(len(incident[v]))
+				candidates = append(candidates, mean)
+			}
+			for i := 0; i < untangleCandidates; i++ {
+				angle, r := 2*math.Pi*rng.Float64(), radius*rng.Float64()
+				candidates = append(candidates, point{
+					x: points[v].x + r*math.Cos(angle),
+					y: points[v].y + r*math.Sin(angle),
+				})
+			}
+			candidates = append(candidates, point{
+				x: box.minX + (box.maxX-box.minX)*rng.Float64(),
+				y: box.minY + (box.maxY-box.minY)*rng.Float64(),
+			})
+
+			best := points[v]
+			for _, c := range candidates {
+				c = onCanvas(c)
+				if !free(v, c) {
+					continue
+				}
+				points[v] = c
+				if count := local(v); count < cost {
+					best, cost = c, count
+				}
+			}
+			points[v] = best
+		}
+		radius = math.Max(radius*0.9, minNodeDistance)
+	}
+	return setPositions(graph, positionsOf(adj.nodes, points)), nil
+}
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/illfate2/graph-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraph_Crossings(t *testing.T) {
+	square := placedGraph(map[uint64][2]uint64{1: {0, 0}, 2: {100, 0}, 3: {100, 100}, 4: {0, 100}},
+		[2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 4}, [2]uint64{4, 1}, [2]uint64{1, 3}, [2]uint64{2, 4})
+	got, err := Graph{}.Crossings(square)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Count)
+	assert.Equal(t, []EdgeCrossing{{First: 5, Second: 6, X: 50, Y: 50}}, got.Crossings)
+
+	square = placedGraph(map[uint64][2]uint64{1: {0, 0}, 2: {100, 0}, 3: {100, 100}, 4: {0, 100}},
+		[2]uint64{1, 3}, [2]uint64{2, 4}, [2]uint64{3, 1}, [2]uint64{4, 2}, [2]uint64{1, 1})
+	got, err = Graph{}.Crossings(square)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Count, "parallel edges are drawn once")
+	assert.Equal(t, []EdgeCrossing{{First: 1, Second: 2, X: 50, Y: 50}}, got.Crossings)
+
+	got, err = Graph{}.Crossings(placedGraph(map[uint64][2]uint64{1: {0, 0}, 2: {100, 0}}, [2]uint64{1, 2}))
+	require.NoError(t, err)
+	assert.Zero(t, got.Count)
+	assert.Empty(t, got.Crossings)
+}
+
+func TestGraph_CrossingsLimits(t *testing.T) {
+	// Every pair of edges without a common node crosses when nodes are
+	// placed on a circle.
+	complete, err := Graph{}.Generate("complete", CompleteGenerator, GeneratorParams{N: 40})
+	require.NoError(t, err)
+	got, err := Graph{}.Crossings(complete)
+	require.NoError(t, err)
+	assert.Equal(t, 91390, got.Count)
+	assert.Len(t, got.Crossings, maxListedCrossings)
+
+	complete, err = Graph{}.Generate("complete", CompleteGenerator, GeneratorParams{N: 101})
+	require.NoError(t, err)
+	_, err = Graph{}.Crossings(complete)
+	assert.Equal(t, ErrTooManyEdges, err)
+	_, err = Graph{}.Untangle(complete, 1)
+	assert.Equal(t, ErrTooManyEdges, err)
+}
+
+func TestGraph_Untangle(t *testing.T) {
+	// A cycle whose nodes are placed on a circle every third step, which
+	// makes a star with many crossings.
+	cycle, err := Graph{}.Generate("cycle", CycleGenerator, GeneratorParams{N: 8})
+	require.NoError(t, err)
+	star := ring(8, ringRadius())
+	positions := make(map[uint64]point, len(cycle.Nodes))
+	for i, n := range cycle.Nodes {
+		positions[n.ID] = star[i*3%8]
+	}
+	cycle = setPositions(cycle, positions)
+
+	k4 := placedGraph(map[uint64][2]uint64{1: {100, 100}, 2: {300, 100}, 3: {300, 300}, 4: {100, 300}},
+		[2]uint64{1, 2}, [2]uint64{2, 3}, [2]uint64{3, 4}, [2]uint64{4, 1}, [2]uint64{1, 3}, [2]uint64{2, 4})
+	k5, err := Graph{}.Generate("k5", CompleteGenerator, GeneratorParams{N: 5})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		graph model.Graph
+		want  int
+	}{
+		{name: "star cycle", graph: cycle, want: 0},
+		{name: "k4", graph: k4, want: 0},
+		{name: "k5", graph: k5, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, err := Graph{}.Crossings(tt.graph)
+			require.NoError(t, err)
+			got, err := Graph{}.Untangle(tt.graph, 1)
+			require.NoError(t, err)
+			after, err := Graph{}.Crossings(got)
+			require.NoError(t, err)
+			assert.True(t, after.Count <= before.Count, "crossings grew from %d to %d", before.Count, after.Count)
+			assert.Equal(t, tt.want, after.Count)
+
+			original := nodePositions(tt.graph)
+			for id, p := range nodePositions(got) {
+				if p != original[id] {
+					assert.True(t, p.x >= canvasMargin && p.x <= canvasWidth-canvasMargin, "node %d x is %v", id, p.x)
+					assert.True(t, p.y >= canvasMargin && p.y <= canvasHeight-canvasMargin, "node %d y is %v", id, p.y)
+				}
+			}
+			again, err := Graph{}.Untangle(tt.graph, 1)
+			require.NoError(t, err)
+			assert.Equal(t, got, again)
+		})
+	}
+}
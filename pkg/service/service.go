@@ -23,6 +23,44 @@ type Service interface {
 	EulerianCycle(graphID, startedNode uint64) ([]model.Node, error)
 	Cartesian(firstGraphID, secondGraphID uint64) (model.Graph, error)
 	IsTree(graphID uint64) bool
+	FindPattern(graphID uint64, pattern model.Graph, induced bool, limit uint64, found func(graph.Match) bool) error
+	Centrality(graphID uint64, kind graph.CentralityKind, normalized bool) (map[uint64]float64, error)
+	Communities(graphID uint64, kind graph.CommunityKind, color bool) (graph.Communities, error)
+	Stats(graphID uint64) (graph.Stats, error)
+	Spectrum(graphID uint64) (graph.Spectrum, error)
+	SpanningTreeCount(graphID uint64) (graph.SpanningTreeCount, error)
+	ArborescenceCount(graphID, root uint64) (graph.SpanningTreeCount, error)
+	Transform(graphID uint64, kind graph.TransformKind, k uint64, save bool) (model.Graph, error)
+	Product(firstGraphID, secondGraphID uint64, kind graph.ProductKind) (model.Graph, error)
+	Combine(firstGraphID, secondGraphID uint64, op graph.SetOperation, matchBy graph.MatchBy) (model.Graph, error)
+	InducedSubgraph(graphID uint64, nodeIDs []uint64) (model.Graph, error)
+	Ego(graphID, node, radius uint64) (model.Graph, error)
+	ContractEdge(graphID, edgeID uint64, keepParallel, keepLoops bool) (model.Graph, error)
+	MergeNodes(graphID uint64, nodeIDs []uint64, keepParallel, keepLoops bool) (model.Graph, error)
+	ReachabilityMatrix(id uint64) (graph.AdjacencyMatrix, error)
+	TransitiveClosure(id uint64) (model.Graph, error)
+	TransitiveReduction(id uint64) (model.Graph, error)
+	Dominators(graphID, root uint64) (graph.Dominators, error)
+	Cores(graphID, k uint64) (graph.Cores, error)
+	Cycles(graphID uint64, enumerate bool, limit uint64) (graph.Cycles, error)
+	Connectivity(graphID uint64) (graph.Connectivity, error)
+	GomoryHuTree(graphID uint64) (model.Graph, error)
+	SteinerTree(graphID uint64, terminals []uint64) (graph.SteinerTree, error)
+	DominatingSet(graphID uint64) (graph.DominatingSet, error)
+	KCenter(graphID, k uint64) (graph.KCenter, error)
+	RootTree(graphID, root uint64) (graph.RootedTree, error)
+	LowestCommonAncestor(graphID, root uint64, nodeIDs []uint64) (uint64, error)
+	PruferCode(graphID uint64) ([]uint64, error)
+	FromPrufer(name string, code []uint64) (model.Graph, error)
+	TreeIsomorphic(firstGraphID, secondGraphID uint64) (bool, error)
+	Centroid(graphID uint64) ([]uint64, error)
+	FromDegreeSequence(name string, degrees []uint64) (graph.GraphicalCheck, model.Graph, error)
+	FromDirectedDegreeSequence(name string, out, in []uint64) (graph.GraphicalCheck, model.Graph, error)
+	Generate(name string, kind graph.GeneratorKind, params graph.GeneratorParams) (model.Graph, error)
+	Layout(graphID uint64, kind graph.LayoutKind, save bool) (model.Graph, error)
+	Geometry(graphID uint64) (graph.Geometry, error)
+	Crossings(graphID uint64) (graph.Crossings, error)
+	Untangle(graphID uint64, seed int64, save bool) (model.Graph, error)
 }
 
 type Graph struct {
@@ -69,6 +107,132 @@ func (g *Graph) IsTree(id uint64) bool {
 	return g.graph.IsTree(foundGraph)
 }
 
+func (g *Graph) FindPattern(graphID uint64, pattern model.Graph, induced bool, limit uint64, found func(graph.Match) bool) error {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return err
+	}
+	g.graph.FindPattern(foundGraph, pattern, induced, limit, found)
+	return nil
+}
+
+func (g *Graph) Centrality(graphID uint64, kind graph.CentralityKind, normalized bool) (map[uint64]float64, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return nil, err
+	}
+	return g.graph.Centrality(foundGraph, kind, normalized)
+}
+
+// Communities detects communities of the graph. With color set every
+// community gets a distinct node color saved back to the stored graph.
+func (g *Graph) Communities(graphID uint64, kind graph.CommunityKind, color bool) (graph.Communities, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.Communities{}, err
+	}
+	communities, err := g.graph.Communities(foundGraph, kind)
+	if err != nil {
+		return graph.Communities{}, err
+	}
+	if color {
+		err = g.UpdateGraph(g.graph.ColorCommunities(foundGraph, communities.Membership))
+		if err != nil {
+			return graph.Communities{}, err
+		}
+	}
+	return communities, nil
+}
+
+func (g *Graph) Stats(graphID uint64) (graph.Stats, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.Stats{}, err
+	}
+	return g.graph.Stats(foundGraph), nil
+}
+
+func (g *Graph) Spectrum(graphID uint64) (graph.Spectrum, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.Spectrum{}, err
+	}
+	return g.graph.Spectrum(foundGraph)
+}
+
+func (g *Graph) SpanningTreeCount(graphID uint64) (graph.SpanningTreeCount, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.SpanningTreeCount{}, err
+	}
+	return g.graph.SpanningTreeCount(foundGraph)
+}
+
+func (g *Graph) ArborescenceCount(graphID, root uint64) (graph.SpanningTreeCount, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.SpanningTreeCount{}, err
+	}
+	return g.graph.ArborescenceCount(foundGraph, root)
+}
+
+// Transform builds a new graph out of the stored one. With save set the
+// result is stored as a new graph and returned with its ID.
+func (g *Graph) Transform(graphID uint64, kind graph.TransformKind, k uint64, save bool) (model.Graph, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	res, err := g.graph.Transform(foundGraph, kind, k)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	if save {
+		return g.save(res)
+	}
+	return res, nil
+}
+
+func (g *Graph) save(graph model.Graph) (model.Graph, error) {
+	id, err := g.CreateGraph(graph)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	return g.Graph(id)
+}
+
+func (g *Graph) InducedSubgraph(graphID uint64, nodeIDs []uint64) (model.Graph, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	return g.graph.InducedSubgraph(foundGraph, nodeIDs), nil
+}
+
+func (g *Graph) Ego(graphID, node, radius uint64) (model.Graph, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	return g.graph.Ego(foundGraph, node, radius)
+}
+
+func (g *Graph) ContractEdge(graphID, edgeID uint64, keepParallel, keepLoops bool) (model.Graph, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	return g.graph.ContractEdge(foundGraph, edgeID, keepParallel, keepLoops)
+}
+
+func (g *Graph) MergeNodes(graphID uint64, nodeIDs []uint64, keepParallel, keepLoops bool) (model.Graph, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	return g.graph.MergeNodes(foundGraph, nodeIDs, keepParallel, keepLoops)
+}
+
 func (g *Graph) Cartesian(firstGraphID, secondGraphID uint64) (model.Graph, error) {
 	firstGraph, err := g.Graph(firstGraphID)
 	if err != nil {
@@ -81,6 +245,30 @@ func (g *Graph) Cartesian(firstGraphID, secondGraphID uint64) (model.Graph, erro
 	return g.graph.Cartesian(firstGraph, secondGraph), nil
 }
 
+func (g *Graph) Product(firstGraphID, secondGraphID uint64, kind graph.ProductKind) (model.Graph, error) {
+	firstGraph, err := g.Graph(firstGraphID)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	secondGraph, err := g.Graph(secondGraphID)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	return g.graph.Product(firstGraph, secondGraph, kind)
+}
+
+func (g *Graph) Combine(firstGraphID, secondGraphID uint64, op graph.SetOperation, matchBy graph.MatchBy) (model.Graph, error) {
+	firstGraph, err := g.Graph(firstGraphID)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	secondGraph, err := g.Graph(secondGraphID)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	return g.graph.Combine(firstGraph, secondGraph, op, matchBy)
+}
+
 func (g *Graph) FindDiameter(id uint64) (uint64, error) {
 	foundGraph, err := g.Graph(id)
 	if err != nil {
@@ -121,6 +309,234 @@ func (g *Graph) AdjacencyMatrix(id uint64) (graph.AdjacencyMatrix, error) {
 	return g.graph.AdjacencyMatrix(foundGraph), nil
 }
 
+func (g *Graph) ReachabilityMatrix(id uint64) (graph.AdjacencyMatrix, error) {
+	foundGraph, err := g.Graph(id)
+	if err != nil {
+		return nil, err
+	}
+	return g.graph.ReachabilityMatrix(foundGraph), nil
+}
+
+func (g *Graph) TransitiveClosure(id uint64) (model.Graph, error) {
+	foundGraph, err := g.Graph(id)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	return g.graph.TransitiveClosure(foundGraph), nil
+}
+
+func (g *Graph) TransitiveReduction(id uint64) (model.Graph, error) {
+	foundGraph, err := g.Graph(id)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	return g.graph.TransitiveReduction(foundGraph)
+}
+
+func (g *Graph) Dominators(graphID, root uint64) (graph.Dominators, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.Dominators{}, err
+	}
+	return g.graph.Dominators(foundGraph, root)
+}
+
+func (g *Graph) Cores(graphID, k uint64) (graph.Cores, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.Cores{}, err
+	}
+	return g.graph.Cores(foundGraph, k), nil
+}
+
+func (g *Graph) Cycles(graphID uint64, enumerate bool, limit uint64) (graph.Cycles, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.Cycles{}, err
+	}
+	return g.graph.Cycles(foundGraph, enumerate, limit)
+}
+
+func (g *Graph) Connectivity(graphID uint64) (graph.Connectivity, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.Connectivity{}, err
+	}
+	return g.graph.Connectivity(foundGraph), nil
+}
+
+func (g *Graph) GomoryHuTree(graphID uint64) (model.Graph, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	return g.graph.GomoryHuTree(foundGraph)
+}
+
+func (g *Graph) SteinerTree(graphID uint64, terminals []uint64) (graph.SteinerTree, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.SteinerTree{}, err
+	}
+	return g.graph.SteinerTree(foundGraph, terminals)
+}
+
+func (g *Graph) DominatingSet(graphID uint64) (graph.DominatingSet, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.DominatingSet{}, err
+	}
+	return g.graph.DominatingSet(foundGraph), nil
+}
+
+func (g *Graph) KCenter(graphID, k uint64) (graph.KCenter, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.KCenter{}, err
+	}
+	return g.graph.KCenter(foundGraph, k)
+}
+
+func (g *Graph) RootTree(graphID, root uint64) (graph.RootedTree, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.RootedTree{}, err
+	}
+	return g.graph.RootTree(foundGraph, root)
+}
+
+func (g *Graph) LowestCommonAncestor(graphID, root uint64, nodeIDs []uint64) (uint64, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return 0, err
+	}
+	return g.graph.LowestCommonAncestor(foundGraph, root, nodeIDs)
+}
+
+func (g *Graph) PruferCode(graphID uint64) ([]uint64, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return nil, err
+	}
+	return g.graph.PruferCode(foundGraph)
+}
+
+// FromPrufer decodes a tree and stores it as a new graph.
+func (g *Graph) FromPrufer(name string, code []uint64) (model.Graph, error) {
+	tree, err := g.graph.FromPrufer(name, code)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	return g.save(tree)
+}
+
+func (g *Graph) TreeIsomorphic(firstGraphID, secondGraphID uint64) (bool, error) {
+	firstGraph, err := g.Graph(firstGraphID)
+	if err != nil {
+		return false, err
+	}
+	secondGraph, err := g.Graph(secondGraphID)
+	if err != nil {
+		return false, err
+	}
+	return g.graph.TreeIsomorphic(firstGraph, secondGraph)
+}
+
+func (g *Graph) Centroid(graphID uint64) ([]uint64, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return nil, err
+	}
+	return g.graph.Centroid(foundGraph)
+}
+
+// FromDegreeSequence stores a graph realising the degree sequence if the
+// sequence is graphical.
+func (g *Graph) FromDegreeSequence(name string, degrees []uint64) (graph.GraphicalCheck, model.Graph, error) {
+	res, check, err := g.graph.RealizeDegrees(name, degrees)
+	if err != nil || !check.Graphical {
+		return check, model.Graph{}, err
+	}
+	res, err = g.save(res)
+	return check, res, err
+}
+
+func (g *Graph) FromDirectedDegreeSequence(name string, out, in []uint64) (graph.GraphicalCheck, model.Graph, error) {
+	res, check, err := g.graph.RealizeDirectedDegrees(name, out, in)
+	if err != nil || !check.Graphical {
+		return check, model.Graph{}, err
+	}
+	res, err = g.save(res)
+	return check, res, err
+}
+
+// Generate stores a new graph of a standard family.
+func (g *Graph) Generate(name string, kind graph.GeneratorKind, params graph.GeneratorParams) (model.Graph, error) {
+	res, err := g.graph.Generate(name, kind, params)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	return g.save(res)
+}
+
+// Layout computes node positions of the graph and edge angles matching
+// them. With save set the positions replace the stored ones.
+func (g *Graph) Layout(graphID uint64, kind graph.LayoutKind, save bool) (model.Graph, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	res, err := g.graph.Layout(foundGraph, kind)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	res = g.graph.Orient(res)
+	if save {
+		err = g.UpdateGraph(res)
+		if err != nil {
+			return model.Graph{}, err
+		}
+	}
+	return res, nil
+}
+
+func (g *Graph) Geometry(graphID uint64) (graph.Geometry, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.Geometry{}, err
+	}
+	return g.graph.Geometry(foundGraph)
+}
+
+func (g *Graph) Crossings(graphID uint64) (graph.Crossings, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return graph.Crossings{}, err
+	}
+	return g.graph.Crossings(foundGraph)
+}
+
+// Untangle moves nodes of the graph to reduce edge crossings, updating edge
+// angles. With save set the positions replace the stored ones.
+func (g *Graph) Untangle(graphID uint64, seed int64, save bool) (model.Graph, error) {
+	foundGraph, err := g.Graph(graphID)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	res, err := g.graph.Untangle(foundGraph, seed)
+	if err != nil {
+		return model.Graph{}, err
+	}
+	res = g.graph.Orient(res)
+	if save {
+		err = g.UpdateGraph(res)
+		if err != nil {
+			return model.Graph{}, err
+		}
+	}
+	return res, nil
+}
+
 func (g *Graph) ShortestPath(graphID, fromNode, toNode uint64) ([]model.Node, error) {
 	foundGraph, err := g.Graph(graphID)
 	if err != nil {
@@ -171,16 +587,20 @@ func (g *Graph) List() ([]model.Graph, error) {
 	return g.repository.List()
 }
 
+// CreateGraph stores the graph with edge angles computed from node
+// positions.
 func (g *Graph) CreateGraph(graph model.Graph) (uint64, error) {
-	return g.repository.CreateGraph(graph)
+	return g.repository.CreateGraph(g.graph.Orient(graph))
 }
 
 func (g *Graph) Graph(id uint64) (model.Graph, error) {
 	return g.repository.Graph(id)
 }
 
+// UpdateGraph stores the graph with edge angles computed from node
+// positions.
 func (g *Graph) UpdateGraph(graph model.Graph) error {
-	return g.repository.UpdateGraph(graph)
+	return g.repository.UpdateGraph(g.graph.Orient(graph))
 }
 
 func (g *Graph) DeleteGraph(id uint64) error {